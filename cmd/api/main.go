@@ -1,9 +1,11 @@
 package main
 
 import (
+	"flag"
 	"log"
 	"novaardiansyah/simple-pos/docs"
 	"novaardiansyah/simple-pos/internal/config"
+	"novaardiansyah/simple-pos/internal/database"
 	"novaardiansyah/simple-pos/internal/middleware"
 	"novaardiansyah/simple-pos/internal/routes"
 	"os"
@@ -34,10 +36,21 @@ import (
 // @description Enter the token with the `Bearer ` prefix, e.g. "Bearer abcde12345"
 
 func main() {
+	migrate := flag.Bool("migrate", false, "Run pending database migrations and exit")
+	flag.Parse()
+
 	config.LoadEnv()
 
 	config.ConnectDatabase()
 
+	if *migrate {
+		if err := database.Migrate(config.DB); err != nil {
+			log.Fatal("Failed to run migrations:", err)
+		}
+		log.Println("Migrations completed successfully!")
+		return
+	}
+
 	app := fiber.New(fiber.Config{
 		AppName: os.Getenv("APP_NAME"),
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
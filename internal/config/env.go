@@ -4,15 +4,17 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 var (
-	AppURL  string
-	AppPort string
-	CdnUrl  string
-	MainUrl string
+	AppURL      string
+	AppPort     string
+	AppTimezone string
+	CdnUrl      string
+	MainUrl     string
 
 	MailHost        string
 	MailPort        int
@@ -21,6 +23,9 @@ var (
 	MailEncryption  string
 	MailFromAddress string
 	MailFromName    string
+
+	IdempotencyTTL   time.Duration
+	IdempotencyLease time.Duration
 )
 
 func LoadEnv() {
@@ -38,6 +43,15 @@ func LoadEnv() {
 		AppPort = "8080"
 	}
 
+	AppTimezone = os.Getenv("APP_TIMEZONE")
+	if AppTimezone == "" {
+		AppTimezone = "Asia/Jakarta"
+	}
+
+	if _, err := time.LoadLocation(AppTimezone); err != nil {
+		log.Fatalf("Invalid APP_TIMEZONE %q: %v", AppTimezone, err)
+	}
+
 	MailHost = os.Getenv("MAIL_HOST")
 	MailPort, _ = strconv.Atoi(os.Getenv("MAIL_PORT"))
 	MailUsername = os.Getenv("MAIL_USERNAME")
@@ -45,4 +59,14 @@ func LoadEnv() {
 	MailEncryption = os.Getenv("MAIL_ENCRYPTION")
 	MailFromAddress = os.Getenv("MAIL_FROM_ADDRESS")
 	MailFromName = os.Getenv("MAIL_FROM_NAME")
+
+	IdempotencyTTL, err = time.ParseDuration(os.Getenv("IDEMPOTENCY_TTL"))
+	if err != nil || IdempotencyTTL <= 0 {
+		IdempotencyTTL = 24 * time.Hour
+	}
+
+	IdempotencyLease, err = time.ParseDuration(os.Getenv("IDEMPOTENCY_LEASE"))
+	if err != nil || IdempotencyLease <= 0 {
+		IdempotencyLease = time.Minute
+	}
 }
@@ -120,6 +120,7 @@ func (ctrl *AuthController) ChangePassword(c *fiber.Ctx) error {
 // @Produce json
 // @Security BearerAuth
 // @Param profile body dto.UpdateProfileRequest true "Update profile"
+// @Param Idempotency-Key header string false "Unique key that makes retrying this request safe"
 // @Success 200 {object} utils.SimpleResponse
 // @Failure 400 {object} utils.SimpleErrorResponse
 // @Failure 401 {object} utils.UnauthorizedResponse
@@ -0,0 +1,273 @@
+/*
+ * Project Name: controllers
+ * File: customer_controller.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/simple-pos-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+package controllers
+
+import (
+	"novaardiansyah/simple-pos/internal/dto"
+	"novaardiansyah/simple-pos/internal/repositories"
+	"novaardiansyah/simple-pos/internal/service"
+	"novaardiansyah/simple-pos/pkg/utils"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thedevsaddam/govalidator"
+	"gorm.io/gorm"
+)
+
+type CustomerController struct {
+	CustomerRepo    *repositories.CustomerRepository
+	CustomerService service.CustomerService
+}
+
+func NewCustomerController(db *gorm.DB) *CustomerController {
+	return &CustomerController{
+		CustomerRepo:    repositories.NewCustomerRepository(db),
+		CustomerService: service.NewCustomerService(db),
+	}
+}
+
+var customerRules = govalidator.MapData{
+	"name":     []string{"required", "min:3"},
+	"phone":    []string{"required"},
+	"email":    []string{"email"},
+	"birthday": []string{"date"},
+}
+
+// Index godoc
+// @Summary List customers
+// @Description Get a paginated list of customers, optionally filtered by name, phone or email
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(15)
+// @Param search query string false "Search by name, phone or email"
+// @Param export query string false "Download every matching customer as a file instead" Enums(csv, xlsx)
+// @Success 200 {object} utils.PaginatedResponse{data=[]CustomerSwagger}
+// @Failure 422 {object} utils.SimpleErrorResponse
+// @Failure 500 {object} utils.Response
+// @Router /customers [get]
+// @Security BearerAuth
+func (ctrl *CustomerController) Index(c *fiber.Ctx) error {
+	search := c.Query("search")
+
+	if export := c.Query("export"); export != "" {
+		format, ok := utils.ParseExportFormat(export)
+		if !ok {
+			return utils.ErrorResponse(c, fiber.StatusUnprocessableEntity, "Invalid export format, use csv or xlsx")
+		}
+		return ctrl.export(c, format, search)
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	perPage, _ := strconv.Atoi(c.Query("per_page", "15"))
+
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = 15
+	}
+
+	total, err := ctrl.CustomerRepo.Count(search)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to count customers")
+	}
+
+	customers, err := ctrl.CustomerRepo.FindAllPaginated(page, perPage, search)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve customers")
+	}
+
+	return utils.PaginatedSuccessResponse(c, "Customers retrieved successfully", customers, page, perPage, total, len(customers))
+}
+
+func (ctrl *CustomerController) export(c *fiber.Ctx, format utils.ExportFormat, search string) error {
+	columns := []utils.ExportColumn{
+		{Header: "ID", Type: utils.ExportNumber},
+		{Header: "Name", Type: utils.ExportText},
+		{Header: "Phone", Type: utils.ExportText},
+		{Header: "Email", Type: utils.ExportText},
+		{Header: "Birthday", Type: utils.ExportText},
+		{Header: "Notes", Type: utils.ExportText},
+		{Header: "Created At", Type: utils.ExportDate},
+	}
+
+	var lastID uint
+	err := utils.StreamExport(c, format, "customers", columns, func() ([][]any, error) {
+		customers, err := ctrl.CustomerRepo.FindBatchAfterID(lastID, 500, search)
+		if err != nil {
+			return nil, err
+		}
+
+		rows := make([][]any, len(customers))
+		for i, customer := range customers {
+			birthday := ""
+			if customer.Birthday != nil {
+				birthday = customer.Birthday.Format("2006-01-02")
+			}
+
+			rows[i] = []any{customer.ID, customer.Name, customer.Phone, customer.Email, birthday, customer.Notes, customer.CreatedAt}
+			lastID = customer.ID
+		}
+		return rows, nil
+	})
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to export customers")
+	}
+
+	return nil
+}
+
+// Show godoc
+// @Summary Get customer details
+// @Description Get detailed information about a specific customer
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Success 200 {object} utils.Response{data=CustomerSwagger}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /customers/{id} [get]
+// @Security BearerAuth
+func (ctrl *CustomerController) Show(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid customer ID")
+	}
+
+	customer, err := ctrl.CustomerRepo.FindByID(uint(id))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Customer not found")
+	}
+
+	return utils.SuccessResponse(c, "Customer retrieved successfully", customer)
+}
+
+// Store godoc
+// @Summary Create a customer
+// @Description Create a customer. The phone number is normalised to E.164 and must be unique.
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param customer body dto.CustomerRequest true "Customer data"
+// @Param Idempotency-Key header string false "Unique key that makes retrying this request safe"
+// @Success 201 {object} utils.Response{data=CustomerSwagger}
+// @Failure 409 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /customers [post]
+// @Security BearerAuth
+func (ctrl *CustomerController) Store(c *fiber.Ctx) error {
+	var req dto.CustomerRequest
+
+	errs := utils.ValidateJSON(c, &req, customerRules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	customer, err := ctrl.CustomerService.Create(req)
+	if err != nil {
+		return customerError(c, err, "Failed to create customer")
+	}
+
+	return utils.CreatedResponse(c, "Customer created successfully", customer)
+}
+
+// Update godoc
+// @Summary Update a customer
+// @Description Update a customer. The phone number is normalised to E.164 and must be unique.
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Param customer body dto.CustomerRequest true "Customer data"
+// @Param Idempotency-Key header string false "Unique key that makes retrying this request safe"
+// @Success 200 {object} utils.SimpleResponse
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Failure 409 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /customers/{id} [put]
+// @Security BearerAuth
+func (ctrl *CustomerController) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid customer ID")
+	}
+
+	customer, err := ctrl.CustomerRepo.FindByID(uint(id))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Customer not found")
+	}
+
+	var req dto.CustomerRequest
+
+	errs := utils.ValidateJSON(c, &req, customerRules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	if err := ctrl.CustomerService.Update(customer, req); err != nil {
+		return customerError(c, err, "Failed to update customer")
+	}
+
+	return utils.SimpleSuccessResponse(c, "Customer updated successfully")
+}
+
+// Destroy godoc
+// @Summary Delete a customer
+// @Description Soft delete a customer
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Param Idempotency-Key header string false "Unique key that makes retrying this request safe"
+// @Success 200 {object} utils.SimpleResponse
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /customers/{id} [delete]
+// @Security BearerAuth
+func (ctrl *CustomerController) Destroy(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid customer ID")
+	}
+
+	if _, err := ctrl.CustomerRepo.FindByID(uint(id)); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Customer not found")
+	}
+
+	if err := ctrl.CustomerRepo.Delete(uint(id)); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to delete customer")
+	}
+
+	return utils.SimpleSuccessResponse(c, "Customer deleted successfully")
+}
+
+func customerError(c *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case "invalid_phone":
+		return utils.ValidationError(c, map[string][]string{
+			"phone": {"The phone field must be a valid phone number"},
+		})
+	case "invalid_birthday":
+		return utils.ValidationError(c, map[string][]string{
+			"birthday": {"The birthday field must be a valid date"},
+		})
+	case "phone_already_used":
+		return utils.ErrorResponse(c, fiber.StatusConflict, "Phone already used by another customer")
+	default:
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,170 @@
+/*
+ * Project Name: controllers
+ * File: document_number_controller.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/simple-pos-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+package controllers
+
+import (
+	"novaardiansyah/simple-pos/internal/config"
+	"novaardiansyah/simple-pos/internal/dto"
+	"novaardiansyah/simple-pos/internal/repositories"
+	"novaardiansyah/simple-pos/internal/service"
+	"novaardiansyah/simple-pos/pkg/utils"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thedevsaddam/govalidator"
+	"gorm.io/gorm"
+)
+
+// Reserved numbers younger than this may still be waiting for their
+// document to commit, so they are not reported as gaps yet.
+const documentNumberGapGrace = 5 * time.Minute
+
+type DocumentNumberController struct {
+	DocumentNumberRepo    *repositories.DocumentNumberRepository
+	DocumentNumberService service.DocumentNumberService
+}
+
+func NewDocumentNumberController(db *gorm.DB) *DocumentNumberController {
+	return &DocumentNumberController{
+		DocumentNumberRepo:    repositories.NewDocumentNumberRepository(db),
+		DocumentNumberService: service.NewDocumentNumberService(db),
+	}
+}
+
+// Patterns godoc
+// @Summary List document number patterns
+// @Description Get the configured numbering patterns, optionally for a single outlet
+// @Tags document-numbers
+// @Accept json
+// @Produce json
+// @Param outlet_code query string false "Outlet code"
+// @Success 200 {object} utils.Response{data=[]models.DocumentNumberPattern}
+// @Failure 500 {object} utils.SimpleErrorResponse
+// @Router /document-numbers/patterns [get]
+// @Security BearerAuth
+func (ctrl *DocumentNumberController) Patterns(c *fiber.Ctx) error {
+	outletCode := strings.ToUpper(c.Query("outlet_code"))
+
+	patterns, err := ctrl.DocumentNumberRepo.FindPatterns(outletCode)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve document number patterns")
+	}
+
+	return utils.SuccessResponse(c, "Document number patterns retrieved successfully", patterns)
+}
+
+// SavePattern godoc
+// @Summary Save a document number pattern
+// @Description Create or replace the numbering pattern for an outlet and document type. Supported tokens are {OUTLET}, {YYYYMMDD}, {YYYYMM}, {YYYY}, {YY}, {MM}, {DD} and {SEQ:n}, where n is at most 12. The date tokens must cover the reset period: a daily reset needs year, month and day, monthly needs year and month, and yearly needs the year.
+// @Tags document-numbers
+// @Accept json
+// @Produce json
+// @Param pattern body dto.DocumentNumberPatternRequest true "Pattern"
+// @Param Idempotency-Key header string false "Unique key that makes retrying this request safe"
+// @Success 200 {object} utils.Response{data=models.DocumentNumberPattern}
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /document-numbers/patterns [put]
+// @Security BearerAuth
+func (ctrl *DocumentNumberController) SavePattern(c *fiber.Ctx) error {
+	var req dto.DocumentNumberPatternRequest
+
+	rules := govalidator.MapData{
+		"outlet_code":   []string{"required", "max:20"},
+		"document_type": []string{"required", "in:invoice,refund,purchase_order,receipt"},
+		"pattern":       []string{"required", "max:100"},
+		"reset":         []string{"required", "in:daily,monthly,yearly,never"},
+	}
+
+	errs := utils.ValidateJSON(c, &req, rules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	pattern, err := ctrl.DocumentNumberService.SavePattern(req)
+	if err != nil {
+		switch err.Error() {
+		case "missing_sequence_token":
+			return utils.ValidationError(c, map[string][]string{
+				"pattern": {"The pattern field must contain a {SEQ} or {SEQ:n} token"},
+			})
+		case "invalid_sequence_width":
+			return utils.ValidationError(c, map[string][]string{
+				"pattern": {"The {SEQ:n} width in the pattern field must not exceed 12 digits"},
+			})
+		case "pattern_too_long":
+			return utils.ValidationError(c, map[string][]string{
+				"pattern": {"The pattern field produces numbers longer than 100 characters"},
+			})
+		case "pattern_period_mismatch":
+			return utils.ValidationError(c, map[string][]string{
+				"pattern": {"The pattern field must contain date tokens for every part of the reset period, e.g. {YYYYMMDD} for daily, {YYYYMM} for monthly or {YYYY} for yearly"},
+			})
+		default:
+			return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to save document number pattern")
+		}
+	}
+
+	return utils.SuccessResponse(c, "Document number pattern saved successfully", pattern)
+}
+
+// Gaps godoc
+// @Summary Report document number gaps
+// @Description List numbers that were reserved but never used, for example because the transaction saving the document was rolled back
+// @Tags document-numbers
+// @Accept json
+// @Produce json
+// @Param outlet_code query string false "Outlet code"
+// @Param document_type query string false "Document type" Enums(invoice, refund, purchase_order, receipt)
+// @Param from query string false "Start date (YYYY-MM-DD), defaults to the first day of this month"
+// @Param to query string false "End date (YYYY-MM-DD), inclusive, defaults to today"
+// @Success 200 {object} utils.Response{data=[]models.DocumentNumber}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 500 {object} utils.SimpleErrorResponse
+// @Router /document-numbers/gaps [get]
+// @Security BearerAuth
+func (ctrl *DocumentNumberController) Gaps(c *fiber.Ctx) error {
+	location, err := time.LoadLocation(config.AppTimezone)
+	if err != nil {
+		location = time.Local
+	}
+
+	now := time.Now().In(location)
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, location)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+
+	if value := c.Query("from"); value != "" {
+		if from, err = time.ParseInLocation("2006-01-02", value, location); err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid from date, use YYYY-MM-DD")
+		}
+	}
+
+	if value := c.Query("to"); value != "" {
+		if to, err = time.ParseInLocation("2006-01-02", value, location); err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid to date, use YYYY-MM-DD")
+		}
+	}
+
+	gaps, err := ctrl.DocumentNumberRepo.FindGaps(
+		strings.ToUpper(c.Query("outlet_code")),
+		c.Query("document_type"),
+		from,
+		to.AddDate(0, 0, 1),
+		time.Now().Add(-documentNumberGapGrace),
+	)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve document number gaps")
+	}
+
+	return utils.SuccessResponse(c, "Document number gaps retrieved successfully", gaps)
+}
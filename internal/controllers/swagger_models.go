@@ -10,3 +10,15 @@ type UserSwagger struct {
 	UpdatedAt time.Time `json:"updated_at"`
 	DeletedAt *string   `json:"deleted_at,omitempty"`
 }
+
+type CustomerSwagger struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Phone     string    `json:"phone" example:"+6281234567890"`
+	Email     *string   `json:"email"`
+	Birthday  *string   `json:"birthday" example:"1995-08-17"`
+	Notes     *string   `json:"notes"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	DeletedAt *string   `json:"deleted_at,omitempty"`
+}
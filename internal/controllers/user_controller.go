@@ -37,11 +37,21 @@ func NewUserController(db *gorm.DB) *UserController {
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param per_page query int false "Items per page" default(15)
+// @Param export query string false "Download every user as a file instead" Enums(csv, xlsx)
 // @Success 200 {object} utils.PaginatedResponse{data=[]UserSwagger}
+// @Failure 422 {object} utils.SimpleErrorResponse
 // @Failure 500 {object} utils.Response
 // @Router /users [get]
 // @Security BearerAuth
 func (ctrl *UserController) Index(c *fiber.Ctx) error {
+	if export := c.Query("export"); export != "" {
+		format, ok := utils.ParseExportFormat(export)
+		if !ok {
+			return utils.ErrorResponse(c, fiber.StatusUnprocessableEntity, "Invalid export format, use csv or xlsx")
+		}
+		return ctrl.export(c, format)
+	}
+
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	perPage, _ := strconv.Atoi(c.Query("per_page", "15"))
 
@@ -112,3 +122,33 @@ func (ctrl *UserController) Me(c *fiber.Ctx) error {
 
 	return utils.SuccessResponse(c, "User retrieved successfully", user)
 }
+
+func (ctrl *UserController) export(c *fiber.Ctx, format utils.ExportFormat) error {
+	columns := []utils.ExportColumn{
+		{Header: "ID", Type: utils.ExportNumber},
+		{Header: "Name", Type: utils.ExportText},
+		{Header: "Email", Type: utils.ExportText},
+		{Header: "Created At", Type: utils.ExportDate},
+		{Header: "Updated At", Type: utils.ExportDate},
+	}
+
+	var lastID uint
+	err := utils.StreamExport(c, format, "users", columns, func() ([][]any, error) {
+		users, err := ctrl.UserRepo.FindBatchAfterID(lastID, 500)
+		if err != nil {
+			return nil, err
+		}
+
+		rows := make([][]any, len(users))
+		for i, user := range users {
+			rows[i] = []any{user.ID, user.Name, user.Email, user.CreatedAt, user.UpdatedAt}
+			lastID = user.ID
+		}
+		return rows, nil
+	})
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to export users")
+	}
+
+	return nil
+}
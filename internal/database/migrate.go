@@ -0,0 +1,68 @@
+package database
+
+import (
+	"embed"
+	"io/fs"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migrate runs every file in migrations/ that is not yet recorded in
+// schema_migrations, in file name order. Each file runs in its own
+// transaction together with its bookkeeping row.
+func Migrate(db *gorm.DB) error {
+	err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version VARCHAR(255) PRIMARY KEY,
+		applied_at TIMESTAMP(0) WITHOUT TIME ZONE NOT NULL
+	)`).Error
+	if err != nil {
+		return err
+	}
+
+	var versions []string
+	if err := db.Raw("SELECT version FROM schema_migrations").Scan(&versions).Error; err != nil {
+		return err
+	}
+
+	applied := make(map[string]bool, len(versions))
+	for _, version := range versions {
+		applied[version] = true
+	}
+
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		version := entry.Name()
+		if applied[version] {
+			continue
+		}
+
+		sql, err := migrationFiles.ReadFile("migrations/" + version)
+		if err != nil {
+			return err
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(string(sql)).Error; err != nil {
+				return err
+			}
+			return tx.Exec("INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)", version, time.Now()).Error
+		})
+		if err != nil {
+			log.Printf("Failed to migrate %s: %v\n", version, err)
+			return err
+		}
+
+		log.Printf("Migrated: %s\n", version)
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS customers (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    phone VARCHAR(20) NOT NULL,
+    email VARCHAR(255) NULL,
+    birthday DATE NULL,
+    notes TEXT NULL,
+    created_at TIMESTAMP(0) WITHOUT TIME ZONE NULL,
+    updated_at TIMESTAMP(0) WITHOUT TIME ZONE NULL,
+    deleted_at TIMESTAMP(0) WITHOUT TIME ZONE NULL
+);
+
+-- A phone number may be reused once the customer holding it is soft deleted.
+CREATE UNIQUE INDEX IF NOT EXISTS customers_phone_unique ON customers (phone) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS customers_deleted_at_index ON customers (deleted_at);
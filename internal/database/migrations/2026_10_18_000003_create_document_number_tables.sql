@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS document_number_patterns (
+    id BIGSERIAL PRIMARY KEY,
+    outlet_code VARCHAR(20) NOT NULL,
+    document_type VARCHAR(30) NOT NULL,
+    pattern VARCHAR(100) NOT NULL,
+    reset VARCHAR(10) NOT NULL,
+    created_at TIMESTAMP(0) WITHOUT TIME ZONE NULL,
+    updated_at TIMESTAMP(0) WITHOUT TIME ZONE NULL
+);
+
+-- SavePattern upserts on this index.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_document_number_patterns_outlet_type ON document_number_patterns (outlet_code, document_type);
+
+CREATE TABLE IF NOT EXISTS document_sequences (
+    id BIGSERIAL PRIMARY KEY,
+    outlet_code VARCHAR(20) NOT NULL,
+    document_type VARCHAR(30) NOT NULL,
+    period VARCHAR(8) NOT NULL,
+    last_number BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP(0) WITHOUT TIME ZONE NULL,
+    updated_at TIMESTAMP(0) WITHOUT TIME ZONE NULL
+);
+
+-- Reserve creates the period's row on this index before locking it.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_document_sequences_outlet_type_period ON document_sequences (outlet_code, document_type, period);
+
+CREATE TABLE IF NOT EXISTS document_numbers (
+    id BIGSERIAL PRIMARY KEY,
+    outlet_code VARCHAR(20) NOT NULL,
+    document_type VARCHAR(30) NOT NULL,
+    period VARCHAR(8) NOT NULL,
+    sequence BIGINT NOT NULL,
+    number VARCHAR(100) NOT NULL,
+    status VARCHAR(10) NOT NULL,
+    reference VARCHAR(100) NULL,
+    used_at TIMESTAMP(0) WITHOUT TIME ZONE NULL,
+    created_at TIMESTAMP(0) WITHOUT TIME ZONE NULL,
+    updated_at TIMESTAMP(0) WITHOUT TIME ZONE NULL
+);
+
+-- A number is never issued twice for the same outlet and document type.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_document_numbers_outlet_type_number ON document_numbers (outlet_code, document_type, number);
+CREATE INDEX IF NOT EXISTS idx_document_numbers_status ON document_numbers (status);
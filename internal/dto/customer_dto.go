@@ -0,0 +1,9 @@
+package dto
+
+type CustomerRequest struct {
+	Name     string `json:"name" validate:"required,min=3"`
+	Phone    string `json:"phone" validate:"required"`
+	Email    string `json:"email" validate:"email"`
+	Birthday string `json:"birthday" validate:"date"`
+	Notes    string `json:"notes"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+type DocumentNumberPatternRequest struct {
+	OutletCode   string `json:"outlet_code" validate:"required,max=20" example:"OUT01"`
+	DocumentType string `json:"document_type" validate:"required" enums:"invoice,refund,purchase_order,receipt"`
+	Pattern      string `json:"pattern" validate:"required,max=100" example:"INV/{OUTLET}/{YYYYMMDD}/{SEQ:4}"`
+	Reset        string `json:"reset" validate:"required" enums:"daily,monthly,yearly,never"`
+}
@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"novaardiansyah/simple-pos/internal/config"
+	"novaardiansyah/simple-pos/internal/models"
+	"novaardiansyah/simple-pos/internal/repositories"
+	"novaardiansyah/simple-pos/pkg/utils"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// Expired keys are swept at most this often, instead of on every new key.
+const idempotencyCleanupInterval = time.Hour
+
+var idempotencyLastCleanup atomic.Int64
+
+// Idempotency replays the stored response when a POST, PUT or DELETE is
+// retried with the same Idempotency-Key header. It must run after Auth,
+// because keys are scoped to the authenticated user.
+func Idempotency(db *gorm.DB) fiber.Handler {
+	IdempotencyKeyRepo := repositories.NewIdempotencyKeyRepository(db)
+
+	return func(c *fiber.Ctx) error {
+		key := c.Get("Idempotency-Key")
+
+		switch c.Method() {
+		case fiber.MethodPost, fiber.MethodPut, fiber.MethodDelete:
+		default:
+			return c.Next()
+		}
+
+		userId, ok := c.Locals("user_id").(uint)
+		if key == "" || !ok {
+			return c.Next()
+		}
+
+		if len(key) > 255 {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Idempotency-Key must not exceed 255 characters")
+		}
+
+		hash := sha256.Sum256([]byte(c.Method() + " " + c.OriginalURL() + "\n" + string(c.Body())))
+		fingerprint := hex.EncodeToString(hash[:])
+
+		record, err := IdempotencyKeyRepo.FindByUserAndKey(userId, key)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to check Idempotency-Key")
+		}
+
+		if err == nil && record.ExpiresAt.Before(time.Now()) {
+			IdempotencyKeyRepo.Delete(record)
+			record = nil
+		}
+
+		if record != nil {
+			if record.Fingerprint != fingerprint {
+				return utils.ErrorResponse(c, fiber.StatusUnprocessableEntity, "Idempotency-Key was already used with a different request")
+			}
+
+			if record.CompletedAt != nil {
+				c.Set("Idempotent-Replayed", "true")
+				c.Set(fiber.HeaderContentType, record.ContentType)
+				return c.Status(record.StatusCode).Send(record.ResponseBody)
+			}
+
+			// A request still in flight after its lease most likely died
+			// before it could release the key, so a retry may take it over.
+			claimed, err := IdempotencyKeyRepo.Claim(record, time.Now().Add(-config.IdempotencyLease), time.Now().Add(config.IdempotencyTTL))
+			if err != nil {
+				return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to store Idempotency-Key")
+			}
+			if !claimed {
+				return utils.ErrorResponse(c, fiber.StatusConflict, "A request with this Idempotency-Key is still being processed")
+			}
+		} else {
+			deleteExpiredIdempotencyKeys(IdempotencyKeyRepo)
+
+			record = &models.IdempotencyKey{
+				UserID:      userId,
+				Key:         key,
+				Fingerprint: fingerprint,
+				ExpiresAt:   time.Now().Add(config.IdempotencyTTL),
+			}
+
+			// The unique (user_id, key) index makes this insert the lock: a
+			// concurrent duplicate fails here and is treated as in flight.
+			if err := IdempotencyKeyRepo.Create(record); err != nil {
+				if errors.Is(err, gorm.ErrDuplicatedKey) {
+					return utils.ErrorResponse(c, fiber.StatusConflict, "A request with this Idempotency-Key is still being processed")
+				}
+				return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to store Idempotency-Key")
+			}
+		}
+
+		err = c.Next()
+
+		// Errors and server failures are not stored, so the client can
+		// safely retry them with the same key.
+		statusCode := c.Response().StatusCode()
+		if err != nil || statusCode >= fiber.StatusInternalServerError {
+			IdempotencyKeyRepo.Delete(record)
+			return err
+		}
+
+		contentType := string(c.Response().Header.ContentType())
+		body := append([]byte(nil), c.Response().Body()...)
+
+		if err := IdempotencyKeyRepo.Complete(record, statusCode, contentType, body); err != nil {
+			IdempotencyKeyRepo.Delete(record)
+		}
+
+		return nil
+	}
+}
+
+func deleteExpiredIdempotencyKeys(repo *repositories.IdempotencyKeyRepository) {
+	now := time.Now().Unix()
+	last := idempotencyLastCleanup.Load()
+
+	if now-last < int64(idempotencyCleanupInterval/time.Second) {
+		return
+	}
+
+	if idempotencyLastCleanup.CompareAndSwap(last, now) {
+		repo.DeleteExpired()
+	}
+}
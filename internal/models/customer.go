@@ -0,0 +1,41 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Customer struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	Name      string         `gorm:"size:255;not null" json:"name"`
+	Phone     string         `gorm:"size:20;not null;uniqueIndex:customers_phone_unique,where:deleted_at IS NULL" json:"phone"`
+	Email     *string        `gorm:"size:255" json:"email"`
+	Birthday  *time.Time     `gorm:"type:date" json:"birthday"`
+	Notes     *string        `gorm:"type:text" json:"notes"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty" swaggertype:"string"`
+}
+
+func (Customer) TableName() string {
+	return "customers"
+}
+
+// MarshalJSON writes the birthday as a plain date, the same YYYY-MM-DD form
+// the API accepts, instead of a midnight UTC timestamp.
+func (c Customer) MarshalJSON() ([]byte, error) {
+	type customer Customer
+
+	var birthday *string
+	if c.Birthday != nil {
+		date := c.Birthday.Format("2006-01-02")
+		birthday = &date
+	}
+
+	return json.Marshal(struct {
+		customer
+		Birthday *string `json:"birthday"`
+	}{customer(c), birthday})
+}
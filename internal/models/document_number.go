@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+const (
+	DocumentTypeInvoice       = "invoice"
+	DocumentTypeRefund        = "refund"
+	DocumentTypePurchaseOrder = "purchase_order"
+	DocumentTypeReceipt       = "receipt"
+)
+
+const (
+	DocumentNumberReserved = "reserved"
+	DocumentNumberUsed     = "used"
+)
+
+type DocumentNumberPattern struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	OutletCode   string    `gorm:"size:20;uniqueIndex:idx_document_number_patterns_outlet_type;not null" json:"outlet_code"`
+	DocumentType string    `gorm:"size:30;uniqueIndex:idx_document_number_patterns_outlet_type;not null" json:"document_type"`
+	Pattern      string    `gorm:"size:100;not null" json:"pattern"`
+	Reset        string    `gorm:"size:10;not null" json:"reset"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (DocumentNumberPattern) TableName() string {
+	return "document_number_patterns"
+}
+
+type DocumentSequence struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	OutletCode   string    `gorm:"size:20;uniqueIndex:idx_document_sequences_outlet_type_period;not null" json:"outlet_code"`
+	DocumentType string    `gorm:"size:30;uniqueIndex:idx_document_sequences_outlet_type_period;not null" json:"document_type"`
+	Period       string    `gorm:"size:8;uniqueIndex:idx_document_sequences_outlet_type_period;not null" json:"period"`
+	LastNumber   int64     `gorm:"not null;default:0" json:"last_number"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (DocumentSequence) TableName() string {
+	return "document_sequences"
+}
+
+type DocumentNumber struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	OutletCode   string     `gorm:"size:20;uniqueIndex:idx_document_numbers_outlet_type_number;not null" json:"outlet_code"`
+	DocumentType string     `gorm:"size:30;uniqueIndex:idx_document_numbers_outlet_type_number;not null" json:"document_type"`
+	Period       string     `gorm:"size:8;not null" json:"period"`
+	Sequence     int64      `gorm:"not null" json:"sequence"`
+	Number       string     `gorm:"size:100;uniqueIndex:idx_document_numbers_outlet_type_number;not null" json:"number"`
+	Status       string     `gorm:"size:10;index;not null" json:"status"`
+	Reference    *string    `gorm:"size:100" json:"reference"`
+	UsedAt       *time.Time `json:"used_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+func (DocumentNumber) TableName() string {
+	return "document_numbers"
+}
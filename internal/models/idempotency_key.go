@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+type IdempotencyKey struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	UserID       uint       `gorm:"uniqueIndex:idx_idempotency_keys_user_key;not null" json:"user_id"`
+	Key          string     `gorm:"size:255;uniqueIndex:idx_idempotency_keys_user_key;not null" json:"key"`
+	Fingerprint  string     `gorm:"size:64;not null" json:"fingerprint"`
+	StatusCode   int        `json:"status_code"`
+	ContentType  string     `gorm:"size:255" json:"content_type"`
+	ResponseBody []byte     `json:"-"`
+	CompletedAt  *time.Time `json:"completed_at"`
+	ExpiresAt    time.Time  `gorm:"index" json:"expires_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"novaardiansyah/simple-pos/internal/models"
+	"novaardiansyah/simple-pos/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+type CustomerRepository struct {
+	db *gorm.DB
+}
+
+func NewCustomerRepository(db *gorm.DB) *CustomerRepository {
+	return &CustomerRepository{db: db}
+}
+
+func (r *CustomerRepository) search(search string) *gorm.DB {
+	query := r.db.Model(&models.Customer{})
+
+	if search != "" {
+		like := "%" + search + "%"
+
+		phoneLike := like
+		if term := utils.PhoneSearchTerm(search); term != "" {
+			phoneLike = "%" + term + "%"
+		}
+
+		query = query.Where("name ILIKE ? OR phone LIKE ? OR email ILIKE ?", like, phoneLike, like)
+	}
+
+	return query
+}
+
+func (r *CustomerRepository) FindAllPaginated(page, limit int, search string) ([]models.Customer, error) {
+	var customers []models.Customer
+	offset := (page - 1) * limit
+	err := r.search(search).Order("name").Offset(offset).Limit(limit).Find(&customers).Error
+	return customers, err
+}
+
+func (r *CustomerRepository) Count(search string) (int64, error) {
+	var count int64
+	err := r.search(search).Count(&count).Error
+	return count, err
+}
+
+func (r *CustomerRepository) FindByID(id uint) (*models.Customer, error) {
+	var customer models.Customer
+	err := r.db.First(&customer, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
+
+// FindBatchAfterID returns up to limit customers matching search with an ID
+// above afterID, in ID order, so exports can page without OFFSET.
+func (r *CustomerRepository) FindBatchAfterID(afterID uint, limit int, search string) ([]models.Customer, error) {
+	var customers []models.Customer
+	err := r.search(search).Where("id > ?", afterID).Order("id").Limit(limit).Find(&customers).Error
+	return customers, err
+}
+
+func (r *CustomerRepository) Create(customer *models.Customer) error {
+	return r.db.Create(customer).Error
+}
+
+func (r *CustomerRepository) UpdateFields(id uint, fields map[string]interface{}) error {
+	return r.db.Model(&models.Customer{}).Where("id = ?", id).Updates(fields).Error
+}
+
+func (r *CustomerRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Customer{}, id).Error
+}
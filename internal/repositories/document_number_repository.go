@@ -0,0 +1,159 @@
+package repositories
+
+import (
+	"errors"
+	"novaardiansyah/simple-pos/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DocumentNumberRepository struct {
+	db *gorm.DB
+}
+
+func NewDocumentNumberRepository(db *gorm.DB) *DocumentNumberRepository {
+	return &DocumentNumberRepository{db: db}
+}
+
+func (r *DocumentNumberRepository) FindPatterns(outletCode string) ([]models.DocumentNumberPattern, error) {
+	var patterns []models.DocumentNumberPattern
+	query := r.db.Order("outlet_code").Order("document_type")
+
+	if outletCode != "" {
+		query = query.Where("outlet_code = ?", outletCode)
+	}
+
+	err := query.Find(&patterns).Error
+	return patterns, err
+}
+
+func (r *DocumentNumberRepository) FindPattern(outletCode, documentType string) (*models.DocumentNumberPattern, error) {
+	var pattern models.DocumentNumberPattern
+	err := r.db.Where("outlet_code = ? AND document_type = ?", outletCode, documentType).First(&pattern).Error
+	if err != nil {
+		return nil, err
+	}
+	return &pattern, nil
+}
+
+func (r *DocumentNumberRepository) SavePattern(pattern *models.DocumentNumberPattern) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "outlet_code"}, {Name: "document_type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"pattern", "reset", "updated_at"}),
+	}).Create(pattern).Error
+}
+
+// maxReserveAttempts bounds how far Reserve skips past numbers that
+// already exist.
+const maxReserveAttempts = 10000
+
+// Reserve takes the next sequence for the period and records the formatted
+// number as reserved. The sequence row is locked with SELECT ... FOR UPDATE,
+// so concurrent terminals queue up instead of reading the same value.
+func (r *DocumentNumberRepository) Reserve(outletCode, documentType, period string, format func(sequence int64) string) (*models.DocumentNumber, error) {
+	var number *models.DocumentNumber
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		sequence := models.DocumentSequence{
+			OutletCode:   outletCode,
+			DocumentType: documentType,
+			Period:       period,
+		}
+
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&sequence).Error; err != nil {
+			return err
+		}
+
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("outlet_code = ? AND document_type = ? AND period = ?", outletCode, documentType, period).
+			First(&sequence).Error
+		if err != nil {
+			return err
+		}
+
+		next, err := nextFreeNumber(sequence.LastNumber, format, func(next int64, formatted string) error {
+			candidate := &models.DocumentNumber{
+				OutletCode:   outletCode,
+				DocumentType: documentType,
+				Period:       period,
+				Sequence:     next,
+				Number:       formatted,
+				Status:       models.DocumentNumberReserved,
+			}
+
+			// The nested transaction is a savepoint, so a duplicate does not
+			// abort the surrounding transaction.
+			err := tx.Transaction(func(tx *gorm.DB) error {
+				return tx.Create(candidate).Error
+			})
+			if err == nil {
+				number = candidate
+			}
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&sequence).Update("last_number", next).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return number, nil
+}
+
+// nextFreeNumber creates the first sequence after last whose number is not
+// taken yet. Changing a pattern's reset restarts its counter under a new
+// period while the printed date can stay the same, e.g. daily to monthly
+// with {YYYYMMDD}, so the first numbers it formats may already exist.
+func nextFreeNumber(last int64, format func(sequence int64) string, create func(sequence int64, number string) error) (int64, error) {
+	for next := last + 1; next <= last+maxReserveAttempts; next++ {
+		err := create(next, format(next))
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		return next, nil
+	}
+
+	return 0, errors.New("document_number_exhausted")
+}
+
+// MarkUsed runs on the caller's transaction, so the number only counts as
+// used if the document it was reserved for is committed too.
+func (r *DocumentNumberRepository) MarkUsed(tx *gorm.DB, id uint, reference string) (int64, error) {
+	now := time.Now()
+
+	result := tx.Model(&models.DocumentNumber{}).
+		Where("id = ? AND status = ?", id, models.DocumentNumberReserved).
+		Updates(map[string]interface{}{
+			"status":    models.DocumentNumberUsed,
+			"reference": reference,
+			"used_at":   &now,
+		})
+
+	return result.RowsAffected, result.Error
+}
+
+func (r *DocumentNumberRepository) FindGaps(outletCode, documentType string, from, to, reservedBefore time.Time) ([]models.DocumentNumber, error) {
+	var numbers []models.DocumentNumber
+
+	query := r.db.Where("status = ? AND created_at >= ? AND created_at < ? AND created_at < ?", models.DocumentNumberReserved, from, to, reservedBefore)
+
+	if outletCode != "" {
+		query = query.Where("outlet_code = ?", outletCode)
+	}
+	if documentType != "" {
+		query = query.Where("document_type = ?", documentType)
+	}
+
+	err := query.Order("outlet_code").Order("document_type").Order("period").Order("sequence").Find(&numbers).Error
+	return numbers, err
+}
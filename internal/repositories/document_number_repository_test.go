@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"errors"
+	"novaardiansyah/simple-pos/pkg/utils"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// fakeNumbering stands in for the sequence rows and the unique index on
+// document_numbers.number, so Reserve's skip-ahead can be checked without a
+// database.
+type fakeNumbering struct {
+	counters map[string]int64
+	issued   map[string]bool
+}
+
+func (f *fakeNumbering) reserve(t *testing.T, pattern, reset string, at time.Time) string {
+	t.Helper()
+
+	period := utils.DocumentPeriod(reset, at)
+	format := func(sequence int64) string {
+		return utils.FormatDocumentNumber(pattern, "OUT01", at, sequence)
+	}
+
+	var number string
+	next, err := nextFreeNumber(f.counters[period], format, func(sequence int64, formatted string) error {
+		if f.issued[formatted] {
+			return gorm.ErrDuplicatedKey
+		}
+		f.issued[formatted] = true
+		number = formatted
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("reserve returned error: %v", err)
+	}
+
+	f.counters[period] = next
+	return number
+}
+
+func TestReserveSurvivesResetSwitchWithinADay(t *testing.T) {
+	const pattern = "INV/{OUTLET}/{YYYYMMDD}/{SEQ:4}"
+	morning := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	noon := morning.Add(3 * time.Hour)
+	evening := morning.Add(9 * time.Hour)
+
+	numbering := &fakeNumbering{counters: map[string]int64{}, issued: map[string]bool{}}
+
+	for i := 0; i < 5; i++ {
+		numbering.reserve(t, pattern, utils.ResetDaily, morning)
+	}
+
+	// Daily to monthly: the new 202610 counter starts at zero, and its first
+	// five numbers were already issued under the daily reset.
+	if got := numbering.reserve(t, pattern, utils.ResetMonthly, noon); got != "INV/OUT01/20261018/0006" {
+		t.Errorf("after switching to monthly got %s, want INV/OUT01/20261018/0006", got)
+	}
+	if got := numbering.reserve(t, pattern, utils.ResetMonthly, noon); got != "INV/OUT01/20261018/0007" {
+		t.Errorf("second monthly number got %s, want INV/OUT01/20261018/0007", got)
+	}
+
+	// Monthly back to daily: the daily counter is still at 5 and catches up
+	// with the numbers issued under the monthly reset.
+	if got := numbering.reserve(t, pattern, utils.ResetDaily, evening); got != "INV/OUT01/20261018/0008" {
+		t.Errorf("after switching back to daily got %s, want INV/OUT01/20261018/0008", got)
+	}
+}
+
+func TestNextFreeNumber(t *testing.T) {
+	format := func(sequence int64) string { return "N" + string(rune('0'+sequence)) }
+
+	next, err := nextFreeNumber(2, format, func(int64, string) error { return nil })
+	if err != nil || next != 3 {
+		t.Errorf("nextFreeNumber = %d, %v, want 3, nil", next, err)
+	}
+
+	failure := errors.New("connection reset")
+	if _, err := nextFreeNumber(0, format, func(int64, string) error { return failure }); !errors.Is(err, failure) {
+		t.Errorf("nextFreeNumber error = %v, want %v", err, failure)
+	}
+
+	if _, err := nextFreeNumber(0, format, func(int64, string) error { return gorm.ErrDuplicatedKey }); err == nil {
+		t.Error("expected an error once every attempt is taken")
+	}
+}
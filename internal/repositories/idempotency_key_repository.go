@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"novaardiansyah/simple-pos/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type IdempotencyKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyKeyRepository(db *gorm.DB) *IdempotencyKeyRepository {
+	return &IdempotencyKeyRepository{db: db}
+}
+
+func (r *IdempotencyKeyRepository) FindByUserAndKey(userID uint, key string) (*models.IdempotencyKey, error) {
+	var record models.IdempotencyKey
+	err := r.db.Where("user_id = ? AND key = ?", userID, key).First(&record).Error
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (r *IdempotencyKeyRepository) Create(record *models.IdempotencyKey) error {
+	return r.db.Create(record).Error
+}
+
+// Claim takes over a request that was started before staleBefore and never
+// completed. It reports false when another request completed or claimed the
+// key first.
+func (r *IdempotencyKeyRepository) Claim(record *models.IdempotencyKey, staleBefore, expiresAt time.Time) (bool, error) {
+	now := time.Now()
+
+	result := r.db.Model(&models.IdempotencyKey{}).
+		Where("id = ? AND completed_at IS NULL AND created_at < ?", record.ID, staleBefore).
+		Updates(map[string]interface{}{
+			"created_at": now,
+			"expires_at": expiresAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	record.CreatedAt = now
+	record.ExpiresAt = expiresAt
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r *IdempotencyKeyRepository) Complete(record *models.IdempotencyKey, statusCode int, contentType string, body []byte) error {
+	now := time.Now()
+
+	return r.db.Model(record).Updates(map[string]interface{}{
+		"status_code":   statusCode,
+		"content_type":  contentType,
+		"response_body": body,
+		"completed_at":  &now,
+	}).Error
+}
+
+func (r *IdempotencyKeyRepository) Delete(record *models.IdempotencyKey) error {
+	return r.db.Delete(record).Error
+}
+
+func (r *IdempotencyKeyRepository) DeleteExpired() error {
+	return r.db.Where("expires_at < ?", time.Now()).Delete(&models.IdempotencyKey{}).Error
+}
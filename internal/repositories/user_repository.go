@@ -27,6 +27,14 @@ func (r *UserRepository) FindAllPaginated(page, limit int) ([]models.User, error
 	return users, err
 }
 
+// FindBatchAfterID returns up to limit users with an ID above afterID, in ID
+// order, so exports can page through the table without OFFSET.
+func (r *UserRepository) FindBatchAfterID(afterID uint, limit int) ([]models.User, error) {
+	var users []models.User
+	err := r.db.Where("id > ?", afterID).Order("id").Limit(limit).Find(&users).Error
+	return users, err
+}
+
 func (r *UserRepository) Count() (int64, error) {
 	var count int64
 	err := r.db.Model(&models.User{}).Count(&count).Error
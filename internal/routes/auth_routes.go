@@ -18,6 +18,6 @@ func AuthRoutes(api fiber.Router, db *gorm.DB) {
 	auth.Get("/validate-token", middleware.Auth(db), authController.ValidateToken)
 	auth.Post("/logout", middleware.Auth(db), authController.Logout)
 	auth.Post("/change-password", middleware.Auth(db), authController.ChangePassword)
-	auth.Put("/profile", middleware.Auth(db), authController.UpdateProfile)
+	auth.Put("/profile", middleware.Auth(db), middleware.Idempotency(db), authController.UpdateProfile)
 	auth.Post("/refresh", authController.RefreshToken)
 }
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"novaardiansyah/simple-pos/internal/controllers"
+	"novaardiansyah/simple-pos/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func CustomerRoutes(api fiber.Router, db *gorm.DB) {
+	customerController := controllers.NewCustomerController(db)
+
+	customers := api.Group("/customers", middleware.Auth(db), middleware.Idempotency(db))
+	customers.Get("/", customerController.Index)
+	customers.Post("/", customerController.Store)
+	customers.Get("/:id", customerController.Show)
+	customers.Put("/:id", customerController.Update)
+	customers.Delete("/:id", customerController.Destroy)
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"novaardiansyah/simple-pos/internal/controllers"
+	"novaardiansyah/simple-pos/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func DocumentNumberRoutes(api fiber.Router, db *gorm.DB) {
+	documentNumberController := controllers.NewDocumentNumberController(db)
+
+	documentNumbers := api.Group("/document-numbers", middleware.Auth(db), middleware.Idempotency(db))
+	documentNumbers.Get("/patterns", documentNumberController.Patterns)
+	documentNumbers.Put("/patterns", documentNumberController.SavePattern)
+	documentNumbers.Get("/gaps", documentNumberController.Gaps)
+}
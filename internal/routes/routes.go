@@ -40,4 +40,6 @@ func SetupRoutes(app *fiber.App) {
 
 	AuthRoutes(api, db)
 	UserRoutes(api, db)
+	CustomerRoutes(api, db)
+	DocumentNumberRoutes(api, db)
 }
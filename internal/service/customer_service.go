@@ -0,0 +1,122 @@
+/*
+ * Project Name: service
+ * File: customer_service.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/simple-pos-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+package service
+
+import (
+	"errors"
+	"novaardiansyah/simple-pos/internal/dto"
+	"novaardiansyah/simple-pos/internal/models"
+	"novaardiansyah/simple-pos/internal/repositories"
+	"novaardiansyah/simple-pos/pkg/utils"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type CustomerService interface {
+	Create(req dto.CustomerRequest) (*models.Customer, error)
+	Update(customer *models.Customer, req dto.CustomerRequest) error
+}
+
+type customerService struct {
+	CustomerRepo *repositories.CustomerRepository
+}
+
+func NewCustomerService(db *gorm.DB) CustomerService {
+	return &customerService{
+		CustomerRepo: repositories.NewCustomerRepository(db),
+	}
+}
+
+func (s *customerService) Create(req dto.CustomerRequest) (*models.Customer, error) {
+	phone, err := utils.NormalizePhoneID(req.Phone)
+	if err != nil {
+		return nil, err
+	}
+
+	birthday, err := parseBirthday(req.Birthday)
+	if err != nil {
+		return nil, err
+	}
+
+	customer := &models.Customer{
+		Name:     strings.TrimSpace(req.Name),
+		Phone:    phone,
+		Email:    optionalString(req.Email),
+		Birthday: birthday,
+		Notes:    optionalString(req.Notes),
+	}
+
+	if err := s.CustomerRepo.Create(customer); err != nil {
+		return nil, customerWriteError(err)
+	}
+
+	return customer, nil
+}
+
+func (s *customerService) Update(customer *models.Customer, req dto.CustomerRequest) error {
+	phone, err := utils.NormalizePhoneID(req.Phone)
+	if err != nil {
+		return err
+	}
+
+	birthday, err := parseBirthday(req.Birthday)
+	if err != nil {
+		return err
+	}
+
+	updateFields := map[string]interface{}{
+		"name":     strings.TrimSpace(req.Name),
+		"phone":    phone,
+		"email":    optionalString(req.Email),
+		"birthday": birthday,
+		"notes":    optionalString(req.Notes),
+	}
+
+	if err := s.CustomerRepo.UpdateFields(customer.ID, updateFields); err != nil {
+		return customerWriteError(err)
+	}
+
+	return nil
+}
+
+// customerWriteError relies on the partial unique index on phone, which
+// also catches two requests racing for the same number.
+func customerWriteError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return errors.New("phone_already_used")
+	}
+	return err
+}
+
+func parseBirthday(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	birthday, err := time.Parse("2006-01-02", strings.ReplaceAll(value, "/", "-"))
+	if err != nil {
+		return nil, errors.New("invalid_birthday")
+	}
+
+	return &birthday, nil
+}
+
+func optionalString(value string) *string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
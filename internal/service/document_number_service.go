@@ -0,0 +1,147 @@
+/*
+ * Project Name: service
+ * File: document_number_service.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/simple-pos-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+package service
+
+import (
+	"errors"
+	"novaardiansyah/simple-pos/internal/config"
+	"novaardiansyah/simple-pos/internal/dto"
+	"novaardiansyah/simple-pos/internal/models"
+	"novaardiansyah/simple-pos/internal/repositories"
+	"novaardiansyah/simple-pos/pkg/utils"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var defaultDocumentNumberPatterns = map[string]models.DocumentNumberPattern{
+	models.DocumentTypeInvoice:       {Pattern: "INV/{OUTLET}/{YYYYMMDD}/{SEQ:4}", Reset: utils.ResetDaily},
+	models.DocumentTypeRefund:        {Pattern: "RFD/{OUTLET}/{YYYYMMDD}/{SEQ:4}", Reset: utils.ResetDaily},
+	models.DocumentTypeReceipt:       {Pattern: "RCP/{OUTLET}/{YYYYMMDD}/{SEQ:4}", Reset: utils.ResetDaily},
+	models.DocumentTypePurchaseOrder: {Pattern: "PO/{OUTLET}/{YYYYMM}/{SEQ:4}", Reset: utils.ResetMonthly},
+}
+
+// maxDocumentNumberLength matches the size of document_numbers.number.
+const maxDocumentNumberLength = 100
+
+type DocumentNumberService interface {
+	Reserve(outletCode, documentType string, at time.Time) (*models.DocumentNumber, error)
+	Confirm(tx *gorm.DB, number *models.DocumentNumber, reference string) error
+	SavePattern(req dto.DocumentNumberPatternRequest) (*models.DocumentNumberPattern, error)
+}
+
+type documentNumberService struct {
+	DocumentNumberRepo *repositories.DocumentNumberRepository
+}
+
+func NewDocumentNumberService(db *gorm.DB) DocumentNumberService {
+	return &documentNumberService{
+		DocumentNumberRepo: repositories.NewDocumentNumberRepository(db),
+	}
+}
+
+// Reserve allocates the next number in its own short transaction, so the
+// sequence lock is not held for the whole checkout. The caller must Confirm
+// it inside the transaction that saves the document; a number that is never
+// confirmed shows up in the gap report.
+func (s *documentNumberService) Reserve(outletCode, documentType string, at time.Time) (*models.DocumentNumber, error) {
+	outletCode = strings.ToUpper(strings.TrimSpace(outletCode))
+
+	if outletCode == "" {
+		return nil, errors.New("invalid_outlet_code")
+	}
+
+	pattern, err := s.DocumentNumberRepo.FindPattern(outletCode, documentType)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+
+		fallback, ok := defaultDocumentNumberPatterns[documentType]
+		if !ok {
+			return nil, errors.New("invalid_document_type")
+		}
+		pattern = &fallback
+	}
+
+	location, err := time.LoadLocation(config.AppTimezone)
+	if err == nil {
+		at = at.In(location)
+	}
+
+	period := utils.DocumentPeriod(pattern.Reset, at)
+
+	return s.DocumentNumberRepo.Reserve(outletCode, documentType, period, func(sequence int64) string {
+		return utils.FormatDocumentNumber(pattern.Pattern, outletCode, at, sequence)
+	})
+}
+
+func (s *documentNumberService) Confirm(tx *gorm.DB, number *models.DocumentNumber, reference string) error {
+	affected, err := s.DocumentNumberRepo.MarkUsed(tx, number.ID, reference)
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return errors.New("document_number_already_used")
+	}
+
+	return nil
+}
+
+func (s *documentNumberService) SavePattern(req dto.DocumentNumberPatternRequest) (*models.DocumentNumberPattern, error) {
+	if _, ok := defaultDocumentNumberPatterns[req.DocumentType]; !ok {
+		return nil, errors.New("invalid_document_type")
+	}
+
+	if !utils.HasSequenceToken(req.Pattern) {
+		return nil, errors.New("missing_sequence_token")
+	}
+
+	if !utils.SequenceWidthValid(req.Pattern) {
+		return nil, errors.New("invalid_sequence_width")
+	}
+
+	outletCode := strings.ToUpper(strings.TrimSpace(req.OutletCode))
+
+	// Format with the widest possible sequence so every number the pattern
+	// can produce fits the number column.
+	longest := utils.FormatDocumentNumber(req.Pattern, outletCode, time.Now(), 999999999999)
+	if len(longest) > maxDocumentNumberLength {
+		return nil, errors.New("pattern_too_long")
+	}
+
+	switch req.Reset {
+	case utils.ResetDaily, utils.ResetMonthly, utils.ResetYearly, utils.ResetNever:
+	default:
+		return nil, errors.New("invalid_reset")
+	}
+
+	if !utils.PatternCoversPeriod(req.Pattern, req.Reset) {
+		return nil, errors.New("pattern_period_mismatch")
+	}
+
+	pattern := &models.DocumentNumberPattern{
+		OutletCode:   outletCode,
+		DocumentType: req.DocumentType,
+		Pattern:      req.Pattern,
+		Reset:        req.Reset,
+	}
+
+	if err := s.DocumentNumberRepo.SavePattern(pattern); err != nil {
+		return nil, err
+	}
+
+	return pattern, nil
+}
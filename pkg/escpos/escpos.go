@@ -0,0 +1,264 @@
+package escpos
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	Paper58 = 32
+	Paper80 = 48
+)
+
+type Format string
+
+const (
+	FormatESCPOS Format = "escpos"
+	FormatText   Format = "text"
+)
+
+type Align byte
+
+const (
+	AlignLeft   Align = 0
+	AlignCenter Align = 1
+	AlignRight  Align = 2
+)
+
+const (
+	esc = 0x1B
+	gs  = 0x1D
+)
+
+type Builder struct {
+	buf    bytes.Buffer
+	width  int
+	format Format
+	align  Align
+	scale  int
+}
+
+func NewBuilder(width int, format Format) *Builder {
+	if width <= 0 {
+		width = Paper58
+	}
+	if format != FormatText {
+		format = FormatESCPOS
+	}
+
+	b := &Builder{width: width, format: format, scale: 1}
+	b.command(esc, '@')
+	return b
+}
+
+func (b *Builder) Bold(on bool) *Builder {
+	b.command(esc, 'E', toggle(on))
+	return b
+}
+
+func (b *Builder) DoubleHeight(on bool) *Builder {
+	b.command(gs, '!', toggle(on))
+	return b
+}
+
+// DoubleSize doubles both width and height, which halves the number of
+// characters that fit on a line. Text output has no large font, so it keeps
+// the full line width.
+func (b *Builder) DoubleSize(on bool) *Builder {
+	if on {
+		b.command(gs, '!', 0x11)
+	} else {
+		b.command(gs, '!', 0x00)
+	}
+
+	if b.format == FormatESCPOS && on {
+		b.scale = 2
+	} else {
+		b.scale = 1
+	}
+	return b
+}
+
+func (b *Builder) Align(align Align) *Builder {
+	b.align = align
+	b.command(esc, 'a', byte(align))
+	return b
+}
+
+func (b *Builder) Line(text string) *Builder {
+	width := b.columns()
+
+	for _, line := range wrap(text, width) {
+		if b.format == FormatText {
+			line = pad(line, width, b.align)
+		}
+		b.buf.WriteString(line)
+		b.buf.WriteByte('\n')
+	}
+	return b
+}
+
+func (b *Builder) Columns(left, right string) *Builder {
+	width := b.columns()
+	rightLen := utf8.RuneCountInString(right)
+
+	// The left text needs at least one column plus a space to share the line.
+	if rightLen >= width-1 {
+		return b.Line(left).Line(right)
+	}
+
+	lines := wrap(left, width-rightLen-1)
+	for i, line := range lines {
+		if i < len(lines)-1 {
+			b.buf.WriteString(line)
+			b.buf.WriteByte('\n')
+			continue
+		}
+		gap := width - utf8.RuneCountInString(line) - rightLen
+		if gap < 1 {
+			gap = 1
+		}
+		b.buf.WriteString(line + strings.Repeat(" ", gap) + right)
+		b.buf.WriteByte('\n')
+	}
+	return b
+}
+
+func (b *Builder) Separator() *Builder {
+	b.buf.WriteString(strings.Repeat("-", b.columns()))
+	b.buf.WriteByte('\n')
+	return b
+}
+
+func (b *Builder) Feed(lines int) *Builder {
+	for i := 0; i < lines; i++ {
+		b.buf.WriteByte('\n')
+	}
+	return b
+}
+
+// QRCode prints a model 2 QR code using the GS ( k function. In text mode
+// the payload is printed as-is so the receipt stays readable.
+func (b *Builder) QRCode(data string, size byte) *Builder {
+	if data == "" {
+		return b
+	}
+
+	if b.format == FormatText {
+		return b.Line(data)
+	}
+
+	if size < 1 || size > 16 {
+		size = 6
+	}
+
+	store := len(data) + 3
+	b.buf.Write([]byte{gs, '(', 'k', 4, 0, 49, 65, 50, 0})
+	b.buf.Write([]byte{gs, '(', 'k', 3, 0, 49, 67, size})
+	b.buf.Write([]byte{gs, '(', 'k', 3, 0, 49, 69, 49})
+	b.buf.Write([]byte{gs, '(', 'k', byte(store % 256), byte(store / 256), 49, 80, 48})
+	b.buf.WriteString(data)
+	b.buf.Write([]byte{gs, '(', 'k', 3, 0, 49, 81, 48})
+	b.buf.WriteByte('\n')
+	return b
+}
+
+func (b *Builder) Cut() *Builder {
+	b.command(gs, 'V', 66, 0)
+	return b
+}
+
+func (b *Builder) Bytes() []byte {
+	return b.buf.Bytes()
+}
+
+func (b *Builder) command(data ...byte) {
+	if b.format == FormatText {
+		return
+	}
+	b.buf.Write(data)
+}
+
+func (b *Builder) columns() int {
+	return b.width / b.scale
+}
+
+func toggle(on bool) byte {
+	if on {
+		return 1
+	}
+	return 0
+}
+
+func pad(text string, width int, align Align) string {
+	gap := width - utf8.RuneCountInString(text)
+	if gap <= 0 {
+		return text
+	}
+
+	switch align {
+	case AlignCenter:
+		return strings.Repeat(" ", gap/2) + text
+	case AlignRight:
+		return strings.Repeat(" ", gap) + text
+	default:
+		return text
+	}
+}
+
+// wrap breaks text on word boundaries, keeping any leading indentation on
+// every wrapped line.
+func wrap(text string, width int) []string {
+	trimmed := strings.TrimLeft(text, " ")
+	indent := text[:len(text)-len(trimmed)]
+
+	if len(indent) >= width {
+		indent = ""
+	}
+	width -= len(indent)
+
+	if width <= 0 {
+		return []string{text}
+	}
+
+	words := strings.Fields(trimmed)
+	if len(words) == 0 {
+		return []string{""}
+	}
+
+	var lines []string
+	current := ""
+
+	for _, word := range words {
+		for utf8.RuneCountInString(word) > width {
+			if current != "" {
+				lines = append(lines, current)
+				current = ""
+			}
+			runes := []rune(word)
+			lines = append(lines, string(runes[:width]))
+			word = string(runes[width:])
+		}
+
+		switch {
+		case current == "":
+			current = word
+		case utf8.RuneCountInString(current)+1+utf8.RuneCountInString(word) <= width:
+			current += " " + word
+		default:
+			lines = append(lines, current)
+			current = word
+		}
+	}
+
+	if current != "" {
+		lines = append(lines, current)
+	}
+
+	for i := range lines {
+		lines[i] = indent + lines[i]
+	}
+
+	return lines
+}
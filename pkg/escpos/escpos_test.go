@@ -0,0 +1,38 @@
+package escpos
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestColumnsLongRightText(t *testing.T) {
+	tests := []struct {
+		name  string
+		left  string
+		right string
+		want  string
+	}{
+		{"fits", "Kasir", "Ayu", "Kasir" + strings.Repeat(" ", 24) + "Ayu\n"},
+		{"one column short", "Kasir", strings.Repeat("x", 31), "Kasir\n" + strings.Repeat("x", 31) + "\n"},
+		{"full width", "Kasir", strings.Repeat("x", 32), "Kasir\n" + strings.Repeat("x", 32) + "\n"},
+		{"room for one column", "Kasir", strings.Repeat("x", 30), "K\na\ns\ni\nr " + strings.Repeat("x", 30) + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(NewBuilder(Paper58, FormatText).Columns(tt.left, tt.right).Bytes())
+			if got != tt.want {
+				t.Errorf("Columns(%q, %q) =\n%q\nwant\n%q", tt.left, tt.right, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderReceiptLongNumber(t *testing.T) {
+	r := Receipt{StoreName: "Warung Nova", Number: "INV/OUTLETBANDUNG/20261018/0001", Cashier: "Ayu"}
+
+	out := string(RenderReceipt(r, Paper58, FormatText))
+	if !strings.Contains(out, "\nINV/OUTLETBANDUNG/20261018/0001\n") {
+		t.Errorf("long number is not printed on its own line:\n%s", out)
+	}
+}
@@ -0,0 +1,137 @@
+package escpos
+
+import (
+	"fmt"
+	"novaardiansyah/simple-pos/pkg/utils"
+	"time"
+)
+
+type ReceiptItem struct {
+	Name      string
+	Quantity  int
+	Price     int64
+	Modifiers []string
+	Note      string
+}
+
+type Receipt struct {
+	StoreName     string
+	Address       string
+	Number        string
+	Cashier       string
+	Table         string
+	Date          time.Time
+	Items         []ReceiptItem
+	Subtotal      int64
+	ServiceCharge int64
+	Tax           int64
+	Discount      int64
+	Total         int64
+	Paid          int64
+	Change        int64
+	Footer        string
+	QRCode        string
+}
+
+type KitchenTicket struct {
+	Number  string
+	Table   string
+	Station string
+	Waiter  string
+	Date    time.Time
+	Items   []ReceiptItem
+}
+
+const receiptDateFormat = "02 Jan 2006 15:04"
+
+func RenderReceipt(r Receipt, width int, format Format) []byte {
+	b := NewBuilder(width, format)
+
+	b.Align(AlignCenter).Bold(true).DoubleHeight(true).Line(r.StoreName).DoubleHeight(false).Bold(false)
+	if r.Address != "" {
+		b.Line(r.Address)
+	}
+	b.Align(AlignLeft).Separator()
+
+	b.Columns("No", r.Number)
+	b.Columns("Tanggal", utils.FormatDateID(r.Date, receiptDateFormat))
+	if r.Cashier != "" {
+		b.Columns("Kasir", r.Cashier)
+	}
+	if r.Table != "" {
+		b.Columns("Meja", r.Table)
+	}
+	b.Separator()
+
+	for _, item := range r.Items {
+		b.Line(item.Name)
+		for _, modifier := range item.Modifiers {
+			b.Line("  + " + modifier)
+		}
+		b.Columns(
+			fmt.Sprintf("  %d x %s", item.Quantity, utils.FormatRupiah(item.Price)),
+			utils.FormatRupiah(item.Price*int64(item.Quantity)),
+		)
+	}
+	b.Separator()
+
+	b.Columns("Subtotal", utils.FormatRupiah(r.Subtotal))
+	if r.Discount != 0 {
+		b.Columns("Diskon", utils.FormatRupiah(-r.Discount))
+	}
+	if r.ServiceCharge != 0 {
+		b.Columns("Service", utils.FormatRupiah(r.ServiceCharge))
+	}
+	if r.Tax != 0 {
+		b.Columns("Pajak", utils.FormatRupiah(r.Tax))
+	}
+	b.Bold(true).Columns("TOTAL", utils.FormatRupiah(r.Total)).Bold(false)
+
+	if r.Paid != 0 {
+		b.Columns("Bayar", utils.FormatRupiah(r.Paid))
+		b.Columns("Kembali", utils.FormatRupiah(r.Change))
+	}
+	b.Separator()
+
+	b.Align(AlignCenter)
+	if r.QRCode != "" {
+		b.QRCode(r.QRCode, 6)
+	}
+	if r.Footer != "" {
+		b.Line(r.Footer)
+	}
+
+	return b.Align(AlignLeft).Feed(3).Cut().Bytes()
+}
+
+func RenderKitchenTicket(t KitchenTicket, width int, format Format) []byte {
+	b := NewBuilder(width, format)
+
+	b.Align(AlignCenter).Bold(true).DoubleHeight(true)
+	if t.Station != "" {
+		b.Line(t.Station)
+	}
+	b.Line(t.Number).DoubleHeight(false).Bold(false)
+	b.Align(AlignLeft).Separator()
+
+	if t.Table != "" {
+		b.Columns("Meja", t.Table)
+	}
+	if t.Waiter != "" {
+		b.Columns("Pelayan", t.Waiter)
+	}
+	b.Columns("Waktu", utils.FormatDateID(t.Date, receiptDateFormat))
+	b.Separator()
+
+	for _, item := range t.Items {
+		b.Bold(true).DoubleSize(true).Line(fmt.Sprintf("%dx %s", item.Quantity, item.Name)).DoubleSize(false).Bold(false)
+		for _, modifier := range item.Modifiers {
+			b.DoubleHeight(true).Line("  + " + modifier).DoubleHeight(false)
+		}
+		if item.Note != "" {
+			b.Line("  * " + item.Note)
+		}
+	}
+
+	return b.Separator().Feed(3).Cut().Bytes()
+}
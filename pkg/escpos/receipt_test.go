@@ -0,0 +1,99 @@
+package escpos
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+var testDate = time.Date(2026, 8, 17, 13, 5, 0, 0, time.UTC)
+
+var testItems = []ReceiptItem{
+	{Name: "Nasi Goreng Spesial Pedas Level Lima", Quantity: 2, Price: 25000, Modifiers: []string{"Telur ceplok", "Tanpa bawang goreng"}, Note: "Sambal dipisah"},
+	{Name: "Es Teh Manis", Quantity: 1, Price: 5000},
+}
+
+var testReceipt = Receipt{
+	StoreName:     "Warung Nova",
+	Address:       "Jl. Merdeka No. 1, Jakarta",
+	Number:        "INV/OUT01/20260817/0001",
+	Cashier:       "Budi",
+	Table:         "A3",
+	Date:          testDate,
+	Items:         testItems,
+	Subtotal:      55000,
+	ServiceCharge: 2750,
+	Tax:           5775,
+	Discount:      5000,
+	Total:         58525,
+	Paid:          100000,
+	Change:        41475,
+	Footer:        "Terima kasih atas kunjungan Anda",
+	QRCode:        "https://simple-pos.novaardiansyah.id/r/0001",
+}
+
+var testTicket = KitchenTicket{
+	Number:  "#12",
+	Table:   "A3",
+	Station: "GRILL",
+	Waiter:  "Siti",
+	Date:    testDate,
+	Items:   testItems,
+}
+
+func TestRenderReceipt(t *testing.T) {
+	for _, width := range []int{Paper58, Paper80} {
+		for _, format := range []Format{FormatESCPOS, FormatText} {
+			name := fmt.Sprintf("receipt_%d_%s", width, format)
+			t.Run(name, func(t *testing.T) {
+				assertGolden(t, name, RenderReceipt(testReceipt, width, format))
+			})
+		}
+	}
+}
+
+func TestRenderKitchenTicket(t *testing.T) {
+	for _, width := range []int{Paper58, Paper80} {
+		for _, format := range []Format{FormatESCPOS, FormatText} {
+			name := fmt.Sprintf("kitchen_%d_%s", width, format)
+			t.Run(name, func(t *testing.T) {
+				assertGolden(t, name, RenderKitchenTicket(testTicket, width, format))
+			})
+		}
+	}
+}
+
+func TestTextOutputHasNoCommands(t *testing.T) {
+	output := append(RenderReceipt(testReceipt, Paper58, FormatText), RenderKitchenTicket(testTicket, Paper58, FormatText)...)
+
+	if bytes.ContainsAny(output, "\x1b\x1d") {
+		t.Fatal("text output contains ESC/POS command bytes")
+	}
+}
+
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("output does not match %s\ngot:\n%q\nwant:\n%q", path, got, want)
+	}
+}
@@ -0,0 +1,171 @@
+package label
+
+import (
+	"errors"
+	"novaardiansyah/simple-pos/pkg/utils"
+	"strings"
+)
+
+type PriceLabel struct {
+	Name    string
+	Price   int64
+	Barcode string
+}
+
+// SheetLayout describes a sticker sheet. All sizes are in millimetres.
+type SheetLayout struct {
+	PageWidth   float64
+	PageHeight  float64
+	Columns     int
+	Rows        int
+	LabelWidth  float64
+	LabelHeight float64
+	MarginTop   float64
+	MarginLeft  float64
+	GapX        float64
+	GapY        float64
+}
+
+// A4Sheet24 is the common A4 sheet of 24 stickers, 3 across and 8 down, at
+// 70 x 37 mm each.
+var A4Sheet24 = SheetLayout{
+	PageWidth:   210,
+	PageHeight:  297,
+	Columns:     3,
+	Rows:        8,
+	LabelWidth:  70,
+	LabelHeight: 37,
+	MarginTop:   0.5,
+}
+
+const (
+	labelPadding   = 3
+	barcodeHeight  = 11
+	maxModuleWidth = 0.33
+	// Bars thinner than this do not scan reliably on a laser printer.
+	minModuleWidth = 0.19
+)
+
+// RenderSheet lays the labels out in rows, adding pages as needed. A
+// 12 or 13 digit GTIN is printed as EAN-13 and any other code as Code 128.
+func RenderSheet(labels []PriceLabel, layout SheetLayout) ([]byte, error) {
+	if layout.Columns < 1 || layout.Rows < 1 || layout.LabelWidth <= 0 || layout.LabelHeight <= 0 {
+		return nil, errors.New("invalid_label_layout")
+	}
+
+	pdf := utils.NewPDF()
+	perPage := layout.Columns * layout.Rows
+
+	var page *utils.PDFPage
+	for i, label := range labels {
+		slot := i % perPage
+		if slot == 0 {
+			page = pdf.AddPage(layout.PageWidth*utils.MmToPt, layout.PageHeight*utils.MmToPt)
+		}
+
+		column := slot % layout.Columns
+		row := slot / layout.Columns
+
+		x := layout.MarginLeft + float64(column)*(layout.LabelWidth+layout.GapX)
+		top := layout.PageHeight - layout.MarginTop - float64(row)*(layout.LabelHeight+layout.GapY)
+
+		if err := drawLabel(page, label, x, top, layout); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(labels) == 0 {
+		pdf.AddPage(layout.PageWidth*utils.MmToPt, layout.PageHeight*utils.MmToPt)
+	}
+
+	return pdf.Bytes(), nil
+}
+
+// drawLabel draws one label whose top-left corner is at x, top in
+// millimetres from the bottom-left of the page.
+func drawLabel(page *utils.PDFPage, label PriceLabel, x, top float64, layout SheetLayout) error {
+	const nameSize, priceSize, digitsSize = 9, 14, 7
+
+	left := x + labelPadding
+	inner := layout.LabelWidth - labelPadding*2
+
+	name := fitText(label.Name, inner*utils.MmToPt, nameSize)
+	page.Text(left*utils.MmToPt, (top-labelPadding)*utils.MmToPt-nameSize, utils.PDFHelveticaBold, nameSize, name)
+	page.Text(left*utils.MmToPt, (top-labelPadding)*utils.MmToPt-nameSize-priceSize-2, utils.PDFHelveticaBold, priceSize, utils.FormatRupiah(label.Price))
+
+	if label.Barcode == "" {
+		return nil
+	}
+
+	modules, quietLeft, quietRight, err := barcodeModules(label.Barcode)
+	if err != nil {
+		return err
+	}
+
+	total := float64(quietLeft + len(modules) + quietRight)
+	moduleWidth := inner / total
+	if moduleWidth > maxModuleWidth {
+		moduleWidth = maxModuleWidth
+	}
+	if moduleWidth < minModuleWidth {
+		return errors.New("barcode_too_long")
+	}
+
+	bottom := top - layout.LabelHeight + labelPadding
+	digitsHeight := digitsSize / utils.MmToPt
+	barsLeft := x + (layout.LabelWidth-total*moduleWidth)/2 + float64(quietLeft)*moduleWidth
+	barsBottom := bottom + digitsHeight + 0.5
+
+	for start := 0; start < len(modules); {
+		if modules[start] != '1' {
+			start++
+			continue
+		}
+
+		end := start
+		for end < len(modules) && modules[end] == '1' {
+			end++
+		}
+
+		page.Rect(
+			(barsLeft+float64(start)*moduleWidth)*utils.MmToPt,
+			barsBottom*utils.MmToPt,
+			float64(end-start)*moduleWidth*utils.MmToPt,
+			barcodeHeight*utils.MmToPt,
+		)
+		start = end
+	}
+
+	// Courier glyphs are 0.6em wide, so the digits can be centred exactly.
+	digitsWidth := float64(len(label.Barcode)) * digitsSize * 0.6
+	digitsLeft := (x+layout.LabelWidth/2)*utils.MmToPt - digitsWidth/2
+	page.Text(digitsLeft, bottom*utils.MmToPt, utils.PDFCourier, digitsSize, label.Barcode)
+
+	return nil
+}
+
+func barcodeModules(code string) (string, int, int, error) {
+	if (len(code) == 12 || len(code) == 13) && utils.ValidateGTIN(code) {
+		modules, err := utils.EAN13Modules(code)
+		return modules, utils.EAN13QuietLeft, utils.EAN13QuietRight, err
+	}
+
+	modules, err := utils.Code128Modules(code)
+	return modules, utils.Code128QuietZone, utils.Code128QuietZone, err
+}
+
+// fitText cuts text that would run past width points. Helvetica has no
+// fixed advance, so 0.6em per character is used as a safe average.
+func fitText(text string, width, size float64) string {
+	text = strings.TrimSpace(text)
+	limit := int(width / (size * 0.6))
+
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	if limit <= 3 {
+		return string(runes[:limit])
+	}
+	return strings.TrimSpace(string(runes[:limit-3])) + "..."
+}
@@ -0,0 +1,52 @@
+package label
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRenderSheetAddsPages(t *testing.T) {
+	labels := make([]PriceLabel, 25)
+	for i := range labels {
+		labels[i] = PriceLabel{Name: "Kopi Susu Gula Aren 250ml", Price: 18000, Barcode: "4006381333931"}
+	}
+	labels[24].Barcode = "SKU-001"
+
+	pdf, err := RenderSheet(labels, A4Sheet24)
+	if err != nil {
+		t.Fatalf("RenderSheet returned error: %v", err)
+	}
+
+	if got := bytes.Count(pdf, []byte("/Type /Page ")); got != 2 {
+		t.Errorf("got %d pages, want 2", got)
+	}
+	if !bytes.Contains(pdf, []byte("/MediaBox [0 0 595.276 841.89]")) {
+		t.Error("pages are not A4")
+	}
+	for _, want := range []string{"Rp18.000", "(4006381333931)", "(SKU-001)"} {
+		if !bytes.Contains(pdf, []byte(want)) {
+			t.Errorf("PDF does not contain %q", want)
+		}
+	}
+}
+
+func TestRenderSheetRejectsBadInput(t *testing.T) {
+	if _, err := RenderSheet(nil, SheetLayout{}); err == nil {
+		t.Error("expected an error for an empty layout")
+	}
+
+	long := PriceLabel{Name: "Terlalu panjang", Price: 1000, Barcode: strings.Repeat("X", 40)}
+	if _, err := RenderSheet([]PriceLabel{long}, A4Sheet24); err == nil {
+		t.Error("expected an error for a barcode too long to fit")
+	}
+}
+
+func TestFitText(t *testing.T) {
+	if got := fitText("Es Teh", 100, 9); got != "Es Teh" {
+		t.Errorf("fitText shortened text that fits: %q", got)
+	}
+	if got := fitText(strings.Repeat("a", 50), 54, 9); got != "aaaaaaa..." {
+		t.Errorf("fitText = %q, want %q", got, "aaaaaaa...")
+	}
+}
@@ -0,0 +1,85 @@
+package receipt
+
+import (
+	"novaardiansyah/simple-pos/pkg/escpos"
+	"novaardiansyah/simple-pos/pkg/utils"
+	"strings"
+)
+
+const EmailTemplate = "templates/emails/receipt.html"
+
+type mailItem struct {
+	Name      string
+	Quantity  int
+	Price     string
+	Total     string
+	Modifiers []string
+	Note      string
+}
+
+func MailData(r escpos.Receipt) map[string]any {
+	items := make([]mailItem, 0, len(r.Items))
+	for _, item := range r.Items {
+		items = append(items, mailItem{
+			Name:      item.Name,
+			Quantity:  item.Quantity,
+			Price:     utils.FormatRupiah(item.Price),
+			Total:     utils.FormatRupiah(item.Price * int64(item.Quantity)),
+			Modifiers: item.Modifiers,
+			Note:      item.Note,
+		})
+	}
+
+	data := map[string]any{
+		"StoreName": r.StoreName,
+		"Address":   r.Address,
+		"Number":    r.Number,
+		"Cashier":   r.Cashier,
+		"Table":     r.Table,
+		"Date":      utils.FormatDateID(r.Date, "Monday, 02 January 2006 15:04"),
+		"Items":     items,
+		"Subtotal":  utils.FormatRupiah(r.Subtotal),
+		"Total":     utils.FormatRupiah(r.Total),
+		"Footer":    r.Footer,
+	}
+
+	if r.Discount != 0 {
+		data["Discount"] = utils.FormatRupiah(-r.Discount)
+	}
+	if r.ServiceCharge != 0 {
+		data["ServiceCharge"] = utils.FormatRupiah(r.ServiceCharge)
+	}
+	if r.Tax != 0 {
+		data["Tax"] = utils.FormatRupiah(r.Tax)
+	}
+	if r.Paid != 0 {
+		data["Paid"] = utils.FormatRupiah(r.Paid)
+		data["Change"] = utils.FormatRupiah(r.Change)
+	}
+
+	return data
+}
+
+// SendEmail mails the receipt as HTML with the PDF copy attached.
+func SendEmail(to string, r escpos.Receipt) error {
+	attachment := utils.MailAttachment{
+		FileName:    FileName(r),
+		ContentType: "application/pdf",
+		Content:     RenderPDF(r),
+	}
+
+	subject := "Struk Pembelian " + r.Number
+	if r.StoreName != "" {
+		subject += " - " + r.StoreName
+	}
+
+	return utils.SendEmailWithAttachments(to, subject, MailData(r), []utils.MailAttachment{attachment}, EmailTemplate)
+}
+
+func FileName(r escpos.Receipt) string {
+	number := strings.NewReplacer("/", "-", "\\", "-", " ", "-").Replace(r.Number)
+	if number == "" {
+		number = utils.FormatDateID(r.Date, "20060102-150405")
+	}
+	return "struk-" + number + ".pdf"
+}
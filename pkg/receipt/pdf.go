@@ -0,0 +1,35 @@
+package receipt
+
+import (
+	"bytes"
+	"novaardiansyah/simple-pos/pkg/escpos"
+	"novaardiansyah/simple-pos/pkg/utils"
+)
+
+const (
+	pdfFontSize = 9
+	pdfLeading  = 11
+	pdfMargin   = 14
+)
+
+// RenderPDF lays the 80mm text receipt out on a single narrow PDF page, so
+// the customer copy matches what the thermal printer prints.
+func RenderPDF(r escpos.Receipt) []byte {
+	text := escpos.RenderReceipt(r, escpos.Paper80, escpos.FormatText)
+	lines := bytes.Split(bytes.TrimRight(text, "\n"), []byte("\n"))
+
+	// Courier glyphs are 0.6em wide, which keeps the columns aligned.
+	width := float64(escpos.Paper80)*pdfFontSize*0.6 + pdfMargin*2
+	height := float64(len(lines))*pdfLeading + pdfMargin*2
+
+	pdf := utils.NewPDF()
+	page := pdf.AddPage(width, height)
+
+	y := height - pdfMargin - pdfFontSize
+	for _, line := range lines {
+		page.Text(pdfMargin, y, utils.PDFCourier, pdfFontSize, string(line))
+		y -= pdfLeading
+	}
+
+	return pdf.Bytes()
+}
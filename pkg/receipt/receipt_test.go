@@ -0,0 +1,83 @@
+package receipt
+
+import (
+	"bytes"
+	"html/template"
+	"novaardiansyah/simple-pos/pkg/escpos"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sampleReceipt() escpos.Receipt {
+	return escpos.Receipt{
+		StoreName: "Warung Nova",
+		Address:   "Jl. Merdeka No. 1, Bandung",
+		Number:    "INV/BDG/20261018/0042",
+		Cashier:   "Ayu",
+		Table:     "7",
+		Date:      time.Date(2026, 10, 18, 19, 30, 0, 0, time.UTC),
+		Items: []escpos.ReceiptItem{
+			{Name: "Nasi Goreng Spesial", Quantity: 2, Price: 25000, Modifiers: []string{"Extra telur"}, Note: "Tidak pedas"},
+			{Name: "Es Teh Manis", Quantity: 1, Price: 5000},
+		},
+		Subtotal:      55000,
+		ServiceCharge: 2750,
+		Tax:           5775,
+		Total:         63525,
+		Paid:          70000,
+		Change:        6475,
+		Footer:        "Terima kasih",
+	}
+}
+
+func TestRenderPDF(t *testing.T) {
+	pdf := RenderPDF(sampleReceipt())
+
+	if !bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")) {
+		t.Fatalf("missing PDF header: %q", pdf[:16])
+	}
+	if !bytes.HasSuffix(pdf, []byte("%%EOF\n")) {
+		t.Fatal("missing PDF trailer")
+	}
+
+	for _, want := range []string{"/BaseFont /Courier", "Warung Nova", "Nasi Goreng Spesial", "Rp63.525"} {
+		if !bytes.Contains(pdf, []byte(want)) {
+			t.Errorf("PDF does not contain %q", want)
+		}
+	}
+}
+
+func TestMailTemplate(t *testing.T) {
+	tmpl, err := template.ParseFiles("../../" + EmailTemplate)
+	if err != nil {
+		t.Fatalf("parse template: %v", err)
+	}
+
+	data := MailData(sampleReceipt())
+	data["Title"] = "Struk Pembelian"
+	data["AuthorName"] = "Nova Ardiansyah"
+	data["Year"] = 2026
+
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, data); err != nil {
+		t.Fatalf("execute template: %v", err)
+	}
+
+	html := body.String()
+	for _, want := range []string{"INV/BDG/20261018/0042", "Minggu, 18 Oktober 2026 19:30", "+ Extra telur", "Rp50.000", "Rp63.525", "Rp6.475"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("email does not contain %q", want)
+		}
+	}
+	if strings.Contains(html, "Diskon") {
+		t.Error("email shows a discount row without a discount")
+	}
+}
+
+func TestFileName(t *testing.T) {
+	got := FileName(escpos.Receipt{Number: "INV/BDG/20261018/0042"})
+	if want := "struk-INV-BDG-20261018-0042.pdf"; got != want {
+		t.Errorf("FileName = %q, want %q", got, want)
+	}
+}
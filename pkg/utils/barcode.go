@@ -0,0 +1,257 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+)
+
+var ean13LeftOdd = []string{
+	"0001101", "0011001", "0010011", "0111101", "0100011",
+	"0110001", "0101111", "0111011", "0110111", "0001011",
+}
+
+var ean13LeftEven = []string{
+	"0100111", "0110011", "0011011", "0100001", "0011101",
+	"0111001", "0000101", "0010001", "0001001", "0010111",
+}
+
+var ean13Right = []string{
+	"1110010", "1100110", "1101100", "1000010", "1011100",
+	"1001110", "1010000", "1000100", "1001000", "1110100",
+}
+
+// The first digit of an EAN-13 is not drawn; it is encoded by the odd (L)
+// and even (G) parity pattern of the left half.
+var ean13Parity = []string{
+	"LLLLLL", "LLGLGG", "LLGGLG", "LLGGGL", "LGLLGG",
+	"LGGLLG", "LGGGLL", "LGLGLG", "LGLGGL", "LGGLGL",
+}
+
+// GTINCheckDigit returns the check digit for the given GTIN digits without
+// their check digit. It works for EAN-8, UPC-A, EAN-13 and GTIN-14.
+func GTINCheckDigit(digits string) (int, error) {
+	if digits == "" || !isDigits(digits) {
+		return 0, errors.New("invalid_barcode")
+	}
+
+	sum := 0
+	weight := 3
+
+	for i := len(digits) - 1; i >= 0; i-- {
+		sum += int(digits[i]-'0') * weight
+		weight = 4 - weight
+	}
+
+	return (10 - sum%10) % 10, nil
+}
+
+func ValidateGTIN(code string) bool {
+	switch len(code) {
+	case 8, 12, 13, 14:
+	default:
+		return false
+	}
+
+	check, err := GTINCheckDigit(code[:len(code)-1])
+	if err != nil {
+		return false
+	}
+
+	return int(code[len(code)-1]-'0') == check
+}
+
+func ValidateEAN13(code string) bool {
+	return len(code) == 13 && ValidateGTIN(code)
+}
+
+func ValidateUPCA(code string) bool {
+	return len(code) == 12 && ValidateGTIN(code)
+}
+
+// Code 128 symbols as alternating bar and space widths, indexed by value.
+// 103, 104 and 105 are the Start A, Start B and Start C symbols.
+var code128Widths = []string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232",
+}
+
+const (
+	code128StartB = 104
+	code128StartC = 105
+	code128Stop   = "2331112"
+)
+
+// Minimum quiet zones in modules. EAN-13 needs more room on the left
+// because the first digit is printed there.
+const (
+	EAN13QuietLeft   = 11
+	EAN13QuietRight  = 7
+	Code128QuietZone = 10
+)
+
+// EAN13Modules returns the bars of an EAN-13 (or a UPC-A, which is printed
+// as an EAN-13 with a leading zero) as a string of '1' for a bar module and
+// '0' for a space module, without the quiet zones.
+func EAN13Modules(code string) (string, error) {
+	if len(code) == 12 {
+		code = "0" + code
+	}
+
+	if !ValidateEAN13(code) {
+		return "", errors.New("invalid_barcode")
+	}
+
+	var modules strings.Builder
+	modules.WriteString("101")
+
+	parity := ean13Parity[code[0]-'0']
+	for i := 1; i <= 6; i++ {
+		digit := code[i] - '0'
+		if parity[i-1] == 'L' {
+			modules.WriteString(ean13LeftOdd[digit])
+		} else {
+			modules.WriteString(ean13LeftEven[digit])
+		}
+	}
+
+	modules.WriteString("01010")
+
+	for i := 7; i <= 12; i++ {
+		modules.WriteString(ean13Right[code[i]-'0'])
+	}
+
+	modules.WriteString("101")
+
+	return modules.String(), nil
+}
+
+// Code128Modules encodes printable ASCII as Code 128. An even number of
+// digits uses code set C, which packs two digits per symbol; anything else
+// uses code set B.
+func Code128Modules(value string) (string, error) {
+	if value == "" {
+		return "", errors.New("invalid_barcode")
+	}
+
+	var values []int
+	if len(value)%2 == 0 && isDigits(value) {
+		values = append(values, code128StartC)
+		for i := 0; i < len(value); i += 2 {
+			values = append(values, int(value[i]-'0')*10+int(value[i+1]-'0'))
+		}
+	} else {
+		values = append(values, code128StartB)
+		for i := 0; i < len(value); i++ {
+			if value[i] < 32 || value[i] > 126 {
+				return "", errors.New("invalid_barcode")
+			}
+			values = append(values, int(value[i]-32))
+		}
+	}
+
+	checksum := values[0]
+	for i := 1; i < len(values); i++ {
+		checksum += values[i] * i
+	}
+	values = append(values, checksum%103)
+
+	var modules strings.Builder
+	for _, v := range values {
+		writeWidths(&modules, code128Widths[v])
+	}
+	writeWidths(&modules, code128Stop)
+
+	return modules.String(), nil
+}
+
+// RenderEAN13 draws an EAN-13 or UPC-A as a PNG. moduleWidth is the width
+// of the thinnest bar in pixels.
+func RenderEAN13(code string, moduleWidth, height int) ([]byte, error) {
+	modules, err := EAN13Modules(code)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderBars(modules, EAN13QuietLeft, EAN13QuietRight, moduleWidth, height)
+}
+
+// RenderCode128 draws a Code 128 barcode as a PNG. moduleWidth is the width
+// of the thinnest bar in pixels.
+func RenderCode128(value string, moduleWidth, height int) ([]byte, error) {
+	modules, err := Code128Modules(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderBars(modules, Code128QuietZone, Code128QuietZone, moduleWidth, height)
+}
+
+func writeWidths(modules *strings.Builder, widths string) {
+	module := "1"
+	for i := 0; i < len(widths); i++ {
+		modules.WriteString(strings.Repeat(module, int(widths[i]-'0')))
+		if module == "1" {
+			module = "0"
+		} else {
+			module = "1"
+		}
+	}
+}
+
+func renderBars(modules string, quietLeft, quietRight, moduleWidth, height int) ([]byte, error) {
+	if moduleWidth < 1 {
+		moduleWidth = 2
+	}
+	if height < 1 {
+		height = 80
+	}
+
+	width := (quietLeft + len(modules) + quietRight) * moduleWidth
+	img := image.NewGray(image.Rect(0, 0, width, height))
+
+	for i := range img.Pix {
+		img.Pix[i] = 0xFF
+	}
+
+	for i, module := range modules {
+		if module != '1' {
+			continue
+		}
+
+		left := (quietLeft + i) * moduleWidth
+		for x := left; x < left+moduleWidth; x++ {
+			for y := 0; y < height; y++ {
+				img.SetGray(x, y, color.Gray{Y: 0})
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
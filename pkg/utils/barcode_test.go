@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestGTINCheckDigit(t *testing.T) {
+	tests := []struct {
+		digits string
+		want   int
+	}{
+		{"400638133393", 1},
+		{"03600029145", 2},
+		{"9638507", 4},
+		{"1001234567890", 2},
+	}
+
+	for _, tt := range tests {
+		got, err := GTINCheckDigit(tt.digits)
+		if err != nil {
+			t.Fatalf("GTINCheckDigit(%q) returned error: %v", tt.digits, err)
+		}
+		if got != tt.want {
+			t.Errorf("GTINCheckDigit(%q) = %d, want %d", tt.digits, got, tt.want)
+		}
+	}
+}
+
+func TestEAN13Modules(t *testing.T) {
+	modules, err := EAN13Modules("4006381333931")
+	if err != nil {
+		t.Fatalf("EAN13Modules returned error: %v", err)
+	}
+	if len(modules) != 95 {
+		t.Fatalf("got %d modules, want 95", len(modules))
+	}
+	if !strings.HasPrefix(modules, "101") || !strings.HasSuffix(modules, "101") || modules[45:50] != "01010" {
+		t.Errorf("guard bars are missing: %s", modules)
+	}
+
+	upc, err := EAN13Modules("036000291452")
+	if err != nil {
+		t.Fatalf("EAN13Modules returned error for a UPC-A: %v", err)
+	}
+	if want, _ := EAN13Modules("0036000291452"); upc != want {
+		t.Error("UPC-A is not encoded as an EAN-13 with a leading zero")
+	}
+
+	if _, err := EAN13Modules("4006381333932"); err == nil {
+		t.Error("expected an error for a wrong check digit")
+	}
+}
+
+func TestCode128Modules(t *testing.T) {
+	modules, err := Code128Modules("Wikipedia")
+	if err != nil {
+		t.Fatalf("Code128Modules returned error: %v", err)
+	}
+
+	// Start B, nine characters and the checksum are 11 modules each, and the
+	// stop symbol is 13.
+	if want := 11*11 + 13; len(modules) != want {
+		t.Fatalf("got %d modules, want %d", len(modules), want)
+	}
+
+	var start, checksum, stop strings.Builder
+	writeWidths(&start, code128Widths[code128StartB])
+	writeWidths(&checksum, code128Widths[88])
+	writeWidths(&stop, code128Stop)
+
+	if !strings.HasPrefix(modules, start.String()) {
+		t.Error("does not begin with Start B")
+	}
+	if got := modules[len(modules)-24 : len(modules)-13]; got != checksum.String() {
+		t.Errorf("checksum symbol = %s, want %s (value 88)", got, checksum.String())
+	}
+	if !strings.HasSuffix(modules, stop.String()) {
+		t.Error("does not end with the stop symbol")
+	}
+
+	digits, err := Code128Modules("12345678")
+	if err != nil {
+		t.Fatalf("Code128Modules returned error: %v", err)
+	}
+	if want := 11*6 + 13; len(digits) != want {
+		t.Errorf("code set C: got %d modules, want %d", len(digits), want)
+	}
+
+	for _, value := range []string{"", "caf\xe9", "tab\there"} {
+		if _, err := Code128Modules(value); err == nil {
+			t.Errorf("expected an error for %q", value)
+		}
+	}
+}
+
+func TestRenderBarcodeQuietZones(t *testing.T) {
+	tests := []struct {
+		name   string
+		render func() ([]byte, error)
+		left   int
+		right  int
+	}{
+		{"EAN-13", func() ([]byte, error) { return RenderEAN13("4006381333931", 1, 10) }, EAN13QuietLeft, EAN13QuietRight},
+		{"Code 128", func() ([]byte, error) { return RenderCode128("SKU-001", 1, 10) }, Code128QuietZone, Code128QuietZone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.render()
+			if err != nil {
+				t.Fatalf("render returned error: %v", err)
+			}
+
+			img, err := png.Decode(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("decode PNG: %v", err)
+			}
+
+			width := img.Bounds().Dx()
+			first, last := -1, -1
+			for x := 0; x < width; x++ {
+				if r, _, _, _ := img.At(x, 0).RGBA(); r == 0 {
+					if first < 0 {
+						first = x
+					}
+					last = x
+				}
+			}
+
+			if first != tt.left {
+				t.Errorf("left quiet zone = %d modules, want %d", first, tt.left)
+			}
+			if got := width - 1 - last; got != tt.right {
+				t.Errorf("right quiet zone = %d modules, want %d", got, tt.right)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ResetDaily   = "daily"
+	ResetMonthly = "monthly"
+	ResetYearly  = "yearly"
+	ResetNever   = "never"
+)
+
+// MaxSequenceWidth caps {SEQ:n}. Twelve digits is far more than any outlet
+// issues, and keeps numbers well inside the 100 character column.
+const MaxSequenceWidth = 12
+
+var sequenceToken = regexp.MustCompile(`\{SEQ(?::(\d+))?\}`)
+
+// DocumentPeriod returns the key a sequence counts within, so the number
+// starts again from 1 whenever the period changes.
+func DocumentPeriod(reset string, t time.Time) string {
+	switch reset {
+	case ResetDaily:
+		return t.Format("20060102")
+	case ResetMonthly:
+		return t.Format("200601")
+	case ResetYearly:
+		return t.Format("2006")
+	default:
+		return ""
+	}
+}
+
+// FormatDocumentNumber fills a pattern such as "INV/{OUTLET}/{YYYYMMDD}/{SEQ:4}".
+// {SEQ:n} pads the sequence with zeros to n digits.
+func FormatDocumentNumber(pattern, outletCode string, t time.Time, sequence int64) string {
+	replacer := strings.NewReplacer(
+		"{OUTLET}", outletCode,
+		"{YYYYMMDD}", t.Format("20060102"),
+		"{YYYYMM}", t.Format("200601"),
+		"{YYYY}", t.Format("2006"),
+		"{YY}", t.Format("06"),
+		"{MM}", t.Format("01"),
+		"{DD}", t.Format("02"),
+	)
+
+	result := replacer.Replace(pattern)
+
+	return sequenceToken.ReplaceAllStringFunc(result, func(token string) string {
+		width, _ := strconv.Atoi(sequenceToken.FindStringSubmatch(token)[1])
+		return fmt.Sprintf("%0*d", width, sequence)
+	})
+}
+
+func HasSequenceToken(pattern string) bool {
+	return sequenceToken.MatchString(pattern)
+}
+
+// SequenceWidthValid reports whether every {SEQ:n} in pattern pads to at
+// most MaxSequenceWidth digits.
+func SequenceWidthValid(pattern string) bool {
+	for _, match := range sequenceToken.FindAllStringSubmatch(pattern, -1) {
+		if match[1] == "" {
+			continue
+		}
+		width, err := strconv.Atoi(match[1])
+		if err != nil || width > MaxSequenceWidth {
+			return false
+		}
+	}
+	return true
+}
+
+// PatternCoversPeriod reports whether the date tokens in pattern are at
+// least as fine as the reset period. Without them the sequence restarts
+// while the printed date stays the same, so the same number comes round
+// again. A pattern that never resets needs no date at all.
+func PatternCoversPeriod(pattern, reset string) bool {
+	year := containsAny(pattern, "{YYYYMMDD}", "{YYYYMM}", "{YYYY}", "{YY}")
+	month := containsAny(pattern, "{YYYYMMDD}", "{YYYYMM}", "{MM}")
+	day := containsAny(pattern, "{YYYYMMDD}", "{DD}")
+
+	switch reset {
+	case ResetDaily:
+		return year && month && day
+	case ResetMonthly:
+		return year && month
+	case ResetYearly:
+		return year
+	default:
+		return true
+	}
+}
+
+func containsAny(value string, tokens ...string) bool {
+	for _, token := range tokens {
+		if strings.Contains(value, token) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPatternCoversPeriod(t *testing.T) {
+	tests := []struct {
+		pattern string
+		reset   string
+		want    bool
+	}{
+		{"INV/{OUTLET}/{YYYYMMDD}/{SEQ:4}", ResetDaily, true},
+		{"INV/{YY}{MM}{DD}/{SEQ:4}", ResetDaily, true},
+		{"INV/{YYYYMM}/{SEQ:4}", ResetDaily, false},
+		{"INV/{MM}{DD}/{SEQ:4}", ResetDaily, false},
+		{"PO/{YYYYMM}/{SEQ:4}", ResetMonthly, true},
+		{"PO/{YYYY}/{MM}/{SEQ:4}", ResetMonthly, true},
+		{"PO/{YYYYMMDD}/{SEQ:4}", ResetMonthly, true},
+		{"PO/{YYYY}/{SEQ:4}", ResetMonthly, false},
+		{"PO/{MM}/{SEQ:4}", ResetMonthly, false},
+		{"RFD/{YY}/{SEQ:6}", ResetYearly, true},
+		{"RFD/{MM}/{SEQ:6}", ResetYearly, false},
+		{"RFD/{SEQ:6}", ResetYearly, false},
+		{"RFD/{SEQ:8}", ResetNever, true},
+	}
+
+	for _, tt := range tests {
+		if got := PatternCoversPeriod(tt.pattern, tt.reset); got != tt.want {
+			t.Errorf("PatternCoversPeriod(%q, %q) = %v, want %v", tt.pattern, tt.reset, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDocumentNumber(t *testing.T) {
+	at := time.Date(2026, 3, 7, 23, 59, 0, 0, time.UTC)
+
+	tests := []struct {
+		pattern  string
+		sequence int64
+		want     string
+	}{
+		{"INV/{OUTLET}/{YYYYMMDD}/{SEQ:4}", 42, "INV/BDG01/20260307/0042"},
+		{"PO-{YYYYMM}-{SEQ:3}", 7, "PO-202603-007"},
+		{"{YY}{MM}{DD}{SEQ:2}", 5, "26030705"},
+		{"RFD/{YYYY}/{SEQ}", 123, "RFD/2026/123"},
+		{"INV/{SEQ:4}", 12345, "INV/12345"},
+		{"{OUTLET}-{SEQ:1}-{SEQ:3}", 9, "BDG01-9-009"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatDocumentNumber(tt.pattern, "BDG01", at, tt.sequence); got != tt.want {
+			t.Errorf("FormatDocumentNumber(%q, %d) = %q, want %q", tt.pattern, tt.sequence, got, tt.want)
+		}
+	}
+}
+
+func TestDocumentPeriod(t *testing.T) {
+	at := time.Date(2026, 3, 7, 23, 59, 0, 0, time.UTC)
+
+	tests := []struct {
+		reset string
+		want  string
+	}{
+		{ResetDaily, "20260307"},
+		{ResetMonthly, "202603"},
+		{ResetYearly, "2026"},
+		{ResetNever, ""},
+	}
+
+	for _, tt := range tests {
+		if got := DocumentPeriod(tt.reset, at); got != tt.want {
+			t.Errorf("DocumentPeriod(%q) = %q, want %q", tt.reset, got, tt.want)
+		}
+	}
+}
+
+func TestSequenceWidthValid(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    bool
+	}{
+		{"INV/{SEQ}", true},
+		{"INV/{SEQ:4}", true},
+		{"INV/{SEQ:12}", true},
+		{"INV/{SEQ:13}", false},
+		{"INV/{SEQ:500}", false},
+		{"INV/{SEQ:4}/{SEQ:99}", false},
+	}
+
+	for _, tt := range tests {
+		if got := SequenceWidthValid(tt.pattern); got != tt.want {
+			t.Errorf("SequenceWidthValid(%q) = %v, want %v", tt.pattern, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package utils
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ExportFormat string
+
+const (
+	ExportCSV  ExportFormat = "csv"
+	ExportXLSX ExportFormat = "xlsx"
+)
+
+type ExportCellType int
+
+const (
+	ExportText ExportCellType = iota
+	ExportNumber
+	ExportCurrency
+	ExportDate
+)
+
+type ExportColumn struct {
+	Header string
+	Type   ExportCellType
+}
+
+type ExportWriter interface {
+	WriteRow(values ...any) error
+	Close() error
+}
+
+func ParseExportFormat(value string) (ExportFormat, bool) {
+	switch ExportFormat(value) {
+	case ExportCSV, ExportXLSX:
+		return ExportFormat(value), true
+	default:
+		return "", false
+	}
+}
+
+func ExportFileName(prefix string, format ExportFormat, t time.Time) string {
+	return fmt.Sprintf("%s_%s.%s", prefix, FormatDateID(t, "02-Jan-2006_150405"), format)
+}
+
+func NewExportWriter(w io.Writer, format ExportFormat, sheetName string, columns []ExportColumn) (ExportWriter, error) {
+	if format == ExportXLSX {
+		return newXLSXWriter(w, sheetName, columns)
+	}
+	return newCSVWriter(w, columns)
+}
+
+// ExportBatch returns the next rows of an export. An empty batch ends it.
+type ExportBatch func() ([][]any, error)
+
+// StreamExport sends the export as a download and writes each batch straight
+// into the response body, so large tables never sit in memory. The first
+// batch is read before anything is sent, so a database failure still gets a
+// normal error response instead of a truncated download.
+func StreamExport(c *fiber.Ctx, format ExportFormat, prefix string, columns []ExportColumn, next ExportBatch) error {
+	rows, err := next()
+	if err != nil {
+		return err
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if format == ExportXLSX {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	// Attachment guesses the content type from the file name, so ours has to
+	// be set after it.
+	c.Attachment(ExportFileName(prefix, format, time.Now()))
+	c.Set(fiber.HeaderContentType, contentType)
+
+	c.Context().SetBodyStreamWriter(func(buf *bufio.Writer) {
+		writer, err := NewExportWriter(buf, format, prefix, columns)
+		if err != nil {
+			log.Printf("Failed to start %s export: %v\n", prefix, err)
+			return
+		}
+
+		for len(rows) > 0 {
+			if err = writeExportRows(writer, rows); err != nil {
+				break
+			}
+			if rows, err = next(); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			log.Printf("Failed to write %s export: %v\n", prefix, err)
+		}
+
+		if err := writer.Close(); err != nil {
+			log.Printf("Failed to finish %s export: %v\n", prefix, err)
+		}
+
+		buf.Flush()
+	})
+
+	return nil
+}
+
+func writeExportRows(w ExportWriter, rows [][]any) error {
+	for _, row := range rows {
+		if err := w.WriteRow(row...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type csvExportWriter struct {
+	writer  *csv.Writer
+	columns []ExportColumn
+}
+
+func newCSVWriter(w io.Writer, columns []ExportColumn) (*csvExportWriter, error) {
+	// Excel only detects UTF-8 in a CSV file when it starts with a BOM.
+	if _, err := w.Write([]byte("\xEF\xBB\xBF")); err != nil {
+		return nil, err
+	}
+
+	writer := &csvExportWriter{writer: csv.NewWriter(w), columns: columns}
+
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.Header
+	}
+
+	if err := writer.writer.Write(headers); err != nil {
+		return nil, err
+	}
+
+	return writer, nil
+}
+
+func (w *csvExportWriter) WriteRow(values ...any) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = exportString(value)
+
+		switch value.(type) {
+		case string, *string:
+			record[i] = csvEscapeFormula(record[i])
+		}
+	}
+
+	if err := w.writer.Write(record); err != nil {
+		return err
+	}
+
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func (w *csvExportWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// csvEscapeFormula stops spreadsheet apps from running free text as a
+// formula, e.g. =HYPERLINK(...), by prefixing it with a quote. Numbers are
+// not text values, so negative amounts are left alone. XLSX cells are
+// written as inline strings and never evaluated.
+func csvEscapeFormula(text string) string {
+	if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
+
+func exportString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case *string:
+		if v == nil {
+			return ""
+		}
+		return *v
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format("2006-01-02 15:04:05")
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return exportString(*v)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
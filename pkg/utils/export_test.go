@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+var exportTestColumns = []ExportColumn{
+	{Header: "ID", Type: ExportNumber},
+	{Header: "Name", Type: ExportText},
+	{Header: "Total", Type: ExportCurrency},
+	{Header: "Created At", Type: ExportDate},
+}
+
+func writeExport(t *testing.T, format ExportFormat, rows ...[]any) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer, err := NewExportWriter(&buf, format, "orders", exportTestColumns)
+	if err != nil {
+		t.Fatalf("NewExportWriter returned error: %v", err)
+	}
+
+	for _, row := range rows {
+		if err := writer.WriteRow(row...); err != nil {
+			t.Fatalf("WriteRow returned error: %v", err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestCSVExport(t *testing.T) {
+	name := "=HYPERLINK(\"http://x\")"
+	created := time.Date(2026, 10, 18, 9, 5, 3, 0, time.UTC)
+
+	got := string(writeExport(t, ExportCSV,
+		[]any{uint(1), "Budi, \"Kasir\"", int64(-25000), created},
+		[]any{uint(2), &name, int64(0), nil},
+		[]any{uint(3), "@SUM(A1)", int64(1), nil},
+		[]any{uint(4), "-1+2", int64(1), nil},
+		[]any{uint(5), "\tTab", int64(1), nil},
+	))
+
+	want := "\xEF\xBB\xBF" +
+		"ID,Name,Total,Created At\n" +
+		"1,\"Budi, \"\"Kasir\"\"\",-25000,2026-10-18 09:05:03\n" +
+		"2,\"'=HYPERLINK(\"\"http://x\"\")\",0,\n" +
+		"3,'@SUM(A1),1,\n" +
+		"4,'-1+2,1,\n" +
+		"5,'\tTab,1,\n"
+
+	if got != want {
+		t.Errorf("CSV =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestXLSXExport(t *testing.T) {
+	created := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	data := writeExport(t, ExportXLSX,
+		[]any{uint(1), "Kopi <Susu> & \"Gula\"", int64(25000), created},
+		[]any{uint(2), "=1+1", int64(0), nil},
+	)
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("output is not a zip archive: %v", err)
+	}
+
+	parts := map[string]string{}
+	for _, file := range archive.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", file.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("read %s: %v", file.Name, err)
+		}
+
+		if err := checkWellFormed(content); err != nil {
+			t.Errorf("%s is not well-formed XML: %v", file.Name, err)
+		}
+		parts[file.Name] = string(content)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("missing part %s", name)
+		}
+	}
+
+	if !strings.Contains(parts["xl/workbook.xml"], `<sheet name="orders"`) {
+		t.Error("sheet is not named after the export")
+	}
+
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>`,
+		`<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">ID</t></is></c>`,
+		`<c r="D1" s="1" t="inlineStr"><is><t xml:space="preserve">Created At</t></is></c>`,
+		`<c r="A2" s="0"><v>1</v></c>`,
+		`<c r="B2" s="0" t="inlineStr"><is><t xml:space="preserve">Kopi &lt;Susu&gt; &amp; &#34;Gula&#34;</t></is></c>`,
+		`<c r="C2" s="2"><v>25000</v></c>`,
+		`<c r="D2" s="3"><v>46313.500000</v></c>`,
+		`<c r="B3" s="0" t="inlineStr"><is><t xml:space="preserve">=1+1</t></is></c>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet does not contain %s", want)
+		}
+	}
+
+	if strings.Contains(sheet, `r="D3"`) {
+		t.Error("a nil date should leave the cell empty")
+	}
+}
+
+func TestExportFileName(t *testing.T) {
+	got := ExportFileName("users", ExportXLSX, time.Date(2026, 10, 18, 9, 5, 3, 0, time.UTC))
+	if want := "users_18-Okt-2026_090503.xlsx"; got != want {
+		t.Errorf("ExportFileName = %q, want %q", got, want)
+	}
+}
+
+func checkWellFormed(content []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		_, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
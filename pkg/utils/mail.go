@@ -2,15 +2,29 @@ package utils
 
 import (
 	"bytes"
+	"encoding/base64"
 	"fmt"
 	"html/template"
+	"mime"
+	"mime/multipart"
 	"net/smtp"
+	"net/textproto"
 	"os"
 	"strconv"
 	"time"
 )
 
+type MailAttachment struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
 func SendEmail(to string, subject string, data map[string]any, templateFiles ...string) error {
+	return SendEmailWithAttachments(to, subject, data, nil, templateFiles...)
+}
+
+func SendEmailWithAttachments(to string, subject string, data map[string]any, attachments []MailAttachment, templateFiles ...string) error {
 	host := os.Getenv("MAIL_HOST")
 	port, _ := strconv.Atoi(os.Getenv("MAIL_PORT"))
 	username := os.Getenv("MAIL_USERNAME")
@@ -48,11 +62,70 @@ func SendEmail(to string, subject string, data map[string]any, templateFiles ...
 	header["MIME-Version"] = "1.0"
 	header["Content-Type"] = "text/html; charset=\"UTF-8\""
 
+	content := body.Bytes()
+
+	if len(attachments) > 0 {
+		var contentType string
+		content, contentType, err = buildMultipartBody(content, attachments)
+		if err != nil {
+			return err
+		}
+		header["Content-Type"] = contentType
+	}
+
 	message := ""
 	for k, v := range header {
 		message += fmt.Sprintf("%s: %s\r\n", k, v)
 	}
-	message += "\r\n" + body.String()
+	message += "\r\n" + string(content)
 
 	return smtp.SendMail(addr, auth, fromAddress, []string{to}, []byte(message))
 }
+
+func buildMultipartBody(html []byte, attachments []MailAttachment) ([]byte, string, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {"text/html; charset=\"UTF-8\""},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := part.Write(html); err != nil {
+		return nil, "", err
+	}
+
+	for _, attachment := range attachments {
+		contentType := attachment.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {contentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName})},
+		})
+		if err != nil {
+			return nil, "", err
+		}
+
+		encoded := base64.StdEncoding.EncodeToString(attachment.Content)
+		for len(encoded) > 76 {
+			if _, err := part.Write([]byte(encoded[:76] + "\r\n")); err != nil {
+				return nil, "", err
+			}
+			encoded = encoded[76:]
+		}
+		if _, err := part.Write([]byte(encoded + "\r\n")); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), "multipart/mixed; boundary=" + writer.Boundary(), nil
+}
@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MmToPt converts millimetres to PDF points.
+const MmToPt = 72 / 25.4
+
+type PDFFont string
+
+// Only the standard Type 1 fonts are used, so nothing has to be embedded.
+const (
+	PDFHelvetica     PDFFont = "F1"
+	PDFHelveticaBold PDFFont = "F2"
+	PDFCourier       PDFFont = "F3"
+)
+
+var pdfFonts = []struct {
+	name     PDFFont
+	baseFont string
+}{
+	{PDFHelvetica, "Helvetica"},
+	{PDFHelveticaBold, "Helvetica-Bold"},
+	{PDFCourier, "Courier"},
+}
+
+type PDF struct {
+	pages []*PDFPage
+}
+
+type PDFPage struct {
+	Width   float64
+	Height  float64
+	content bytes.Buffer
+}
+
+func NewPDF() *PDF {
+	return &PDF{}
+}
+
+// AddPage adds a page of the given size in points. Coordinates on the page
+// start at the bottom-left corner.
+func (p *PDF) AddPage(width, height float64) *PDFPage {
+	page := &PDFPage{Width: width, Height: height}
+	p.pages = append(p.pages, page)
+	return page
+}
+
+func (pg *PDFPage) Text(x, y float64, font PDFFont, size float64, text string) {
+	fmt.Fprintf(&pg.content, "BT /%s %s Tf %s %s Td (", font, pdfNumber(size), pdfNumber(x), pdfNumber(y))
+	pg.content.Write(pdfEscape(text))
+	pg.content.WriteString(") Tj ET\n")
+}
+
+// Rect draws a filled black rectangle.
+func (pg *PDFPage) Rect(x, y, width, height float64) {
+	fmt.Fprintf(&pg.content, "%s %s %s %s re f\n", pdfNumber(x), pdfNumber(y), pdfNumber(width), pdfNumber(height))
+}
+
+func (p *PDF) Bytes() []byte {
+	var buf bytes.Buffer
+	var offsets []int
+
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+
+	// Objects 1 and 2 are the catalog and page tree, followed by the fonts and
+	// then a page object and content stream for every page.
+	fontStart := 3
+	pageStart := fontStart + len(pdfFonts)
+
+	var kids, fonts bytes.Buffer
+	for i := range p.pages {
+		fmt.Fprintf(&kids, "%d 0 R ", pageStart+i*2)
+	}
+	for i, font := range pdfFonts {
+		fmt.Fprintf(&fonts, "/%s %d 0 R ", font.name, fontStart+i)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", bytes.TrimSpace(kids.Bytes()), len(p.pages)))
+
+	for _, font := range pdfFonts {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", font.baseFont))
+	}
+
+	for i, page := range p.pages {
+		object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << %s>> >> /Contents %d 0 R >>",
+			pdfNumber(page.Width), pdfNumber(page.Height), fonts.String(), pageStart+i*2+1,
+		))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.content.Len(), page.content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.Bytes()
+}
+
+// pdfNumber keeps three decimals, well below a printer dot, so millimetre
+// conversions do not fill the file with float noise.
+func pdfNumber(value float64) string {
+	number := strconv.FormatFloat(value, 'f', 3, 64)
+	number = strings.TrimRight(number, "0")
+	number = strings.TrimSuffix(number, ".")
+	if number == "-0" {
+		return "0"
+	}
+	return number
+}
+
+// pdfEscape encodes text for a literal string in WinAnsiEncoding. Characters
+// outside Latin-1 are replaced with a question mark.
+func pdfEscape(text string) []byte {
+	var buf bytes.Buffer
+
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			buf.WriteByte('\\')
+			buf.WriteByte(byte(r))
+		case r >= 32 && r < 127, r >= 160 && r <= 255:
+			buf.WriteByte(byte(r))
+		default:
+			buf.WriteByte('?')
+		}
+	}
+
+	return buf.Bytes()
+}
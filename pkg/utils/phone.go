@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+)
+
+// NormalizePhoneID converts an Indonesian phone number written in any of the
+// usual local forms (0812..., 62812..., +62 812-..., 812...) into E.164.
+// Numbers that already carry another country code are kept as they are.
+func NormalizePhoneID(phone string) (string, error) {
+	phone = strings.TrimSpace(phone)
+	hasPlus := strings.HasPrefix(phone, "+")
+
+	var digits strings.Builder
+	for i, r := range phone {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')' || (r == '+' && i == 0):
+			continue
+		default:
+			return "", errors.New("invalid_phone")
+		}
+	}
+
+	number := digits.String()
+
+	switch {
+	case hasPlus:
+		if strings.HasPrefix(number, "0") {
+			return "", errors.New("invalid_phone")
+		}
+	case strings.HasPrefix(number, "00"):
+		number = number[2:]
+	case strings.HasPrefix(number, "62"):
+	case strings.HasPrefix(number, "0"):
+		number = "62" + number[1:]
+	default:
+		number = "62" + number
+	}
+
+	if strings.HasPrefix(number, "620") {
+		number = "62" + number[3:]
+	}
+
+	// E.164 allows at most 15 digits; Indonesian numbers have 8 to 12 digits
+	// after the 62 country code.
+	if strings.HasPrefix(number, "62") {
+		if len(number) < 10 || len(number) > 14 {
+			return "", errors.New("invalid_phone")
+		}
+	} else if len(number) < 8 || len(number) > 15 {
+		return "", errors.New("invalid_phone")
+	}
+
+	return "+" + number, nil
+}
+
+// PhoneSearchTerm turns a full or partial phone number, typed the way it is
+// usually written locally, into the digits NormalizePhoneID stores, so a
+// search for "0812" finds "+62812...". It returns "" when search does not
+// look like a phone number.
+func PhoneSearchTerm(search string) string {
+	var digits strings.Builder
+	for _, r := range strings.TrimSpace(search) {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')' || r == '+':
+			continue
+		default:
+			return ""
+		}
+	}
+
+	number := digits.String()
+
+	switch {
+	case strings.HasPrefix(number, "00"):
+		return number[2:]
+	case strings.HasPrefix(number, "0"):
+		return "62" + number[1:]
+	default:
+		return number
+	}
+}
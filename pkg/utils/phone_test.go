@@ -0,0 +1,81 @@
+package utils
+
+import "testing"
+
+func TestNormalizePhoneID(t *testing.T) {
+	tests := []struct {
+		name  string
+		phone string
+		want  string
+	}{
+		{"local mobile", "0812-3456-7890", "+6281234567890"},
+		{"country code without plus", "6281234567890", "+6281234567890"},
+		{"E.164", "+6281234567890", "+6281234567890"},
+		{"plus with trunk zero", "+62 0812 3456 7890", "+6281234567890"},
+		{"international prefix", "0062 812 3456 7890", "+6281234567890"},
+		{"bare subscriber number", "812.3456.7890", "+6281234567890"},
+		{"landline", "(022) 1234567", "+62221234567"},
+		{"jakarta landline", "021-5550123", "+62215550123"},
+		{"foreign number", "+1 415 555 2671", "+14155552671"},
+		{"foreign with international prefix", "0065 6123 4567", "+6561234567"},
+		{"surrounding spaces", "  081234567890  ", "+6281234567890"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizePhoneID(tt.phone)
+			if err != nil {
+				t.Fatalf("NormalizePhoneID(%q) returned error: %v", tt.phone, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizePhoneID(%q) = %q, want %q", tt.phone, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizePhoneIDRejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		phone string
+	}{
+		{"empty", ""},
+		{"letters", "0812abc7890"},
+		{"double plus", "++6281234567890"},
+		{"plus in the middle", "62+81234567890"},
+		{"plus with leading zero", "+0812345678"},
+		{"too short", "0812"},
+		{"too long for Indonesia", "+62 8123 4567 8901 23"},
+		{"too short abroad", "+1 415"},
+		{"too long for E.164", "+1 4155 5526 7123 456"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := NormalizePhoneID(tt.phone); err == nil {
+				t.Errorf("NormalizePhoneID(%q) = %q, want an error", tt.phone, got)
+			}
+		})
+	}
+}
+
+func TestPhoneSearchTerm(t *testing.T) {
+	tests := []struct {
+		search string
+		want   string
+	}{
+		{"0812", "62812"},
+		{"0812-3456", "628123456"},
+		{"+62 812", "62812"},
+		{"0062812", "62812"},
+		{"3456", "3456"},
+		{"Budi", ""},
+		{"budi@mail.com", ""},
+	}
+
+	for _, tt := range tests {
+		if got := PhoneSearchTerm(tt.search); got != tt.want {
+			t.Errorf("PhoneSearchTerm(%q) = %q, want %q", tt.search, got, tt.want)
+		}
+	}
+}
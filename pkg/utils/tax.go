@@ -0,0 +1,230 @@
+package utils
+
+import "errors"
+
+type TaxMode string
+
+const (
+	TaxExclusive TaxMode = "exclusive"
+	TaxInclusive TaxMode = "inclusive"
+)
+
+type RoundingRule string
+
+const (
+	RoundNone    RoundingRule = "none"
+	RoundNearest RoundingRule = "nearest"
+	RoundUp      RoundingRule = "up"
+	RoundDown    RoundingRule = "down"
+)
+
+type TaxConfig struct {
+	TaxName           string
+	TaxRate           int
+	ServiceChargeRate int
+	Mode              TaxMode
+	ServiceBeforeTax  bool
+	ExemptCategories  []string
+	Rounding          RoundingRule
+	RoundingUnit      int64
+}
+
+type TaxLine struct {
+	Name     string
+	Category string
+	Amount   int64
+}
+
+type TaxLineBreakdown struct {
+	Name          string `json:"name"`
+	Category      string `json:"category"`
+	Net           int64  `json:"net"`
+	ServiceCharge int64  `json:"service_charge"`
+	Tax           int64  `json:"tax"`
+	Total         int64  `json:"total"`
+	Exempt        bool   `json:"exempt"`
+}
+
+type TaxBreakdown struct {
+	TaxName                string             `json:"tax_name"`
+	TaxRate                string             `json:"tax_rate"`
+	ServiceChargeRate      string             `json:"service_charge_rate"`
+	Mode                   TaxMode            `json:"mode"`
+	Lines                  []TaxLineBreakdown `json:"lines"`
+	Subtotal               int64              `json:"subtotal"`
+	ServiceCharge          int64              `json:"service_charge"`
+	Tax                    int64              `json:"tax"`
+	Rounding               int64              `json:"rounding"`
+	Total                  int64              `json:"total"`
+	FormattedSubtotal      string             `json:"formatted_subtotal"`
+	FormattedServiceCharge string             `json:"formatted_service_charge"`
+	FormattedTax           string             `json:"formatted_tax"`
+	FormattedRounding      string             `json:"formatted_rounding"`
+	FormattedTotal         string             `json:"formatted_total"`
+}
+
+// CalculateTax splits every line into net, service charge and tax, then
+// applies the rounding rule to the order total. An empty Mode is treated as
+// exclusive and an empty Rounding as RoundNone. Negative rates are rejected.
+func CalculateTax(config TaxConfig, lines []TaxLine) (TaxBreakdown, error) {
+	switch config.Mode {
+	case "", TaxExclusive, TaxInclusive:
+	default:
+		return TaxBreakdown{}, errors.New("invalid_tax_mode")
+	}
+
+	if !isRoundingRule(config.Rounding) {
+		return TaxBreakdown{}, errors.New("invalid_rounding_rule")
+	}
+
+	if config.TaxRate < 0 || config.ServiceChargeRate < 0 {
+		return TaxBreakdown{}, errors.New("invalid_tax_rate")
+	}
+
+	exempt := make(map[string]bool, len(config.ExemptCategories))
+	for _, category := range config.ExemptCategories {
+		exempt[category] = true
+	}
+
+	result := TaxBreakdown{
+		TaxName:           config.TaxName,
+		TaxRate:           FormatPercent(config.TaxRate),
+		ServiceChargeRate: FormatPercent(config.ServiceChargeRate),
+		Mode:              config.Mode,
+		Lines:             make([]TaxLineBreakdown, 0, len(lines)),
+	}
+
+	for _, line := range lines {
+		taxRate := config.TaxRate
+		if exempt[line.Category] {
+			taxRate = 0
+		}
+
+		var breakdown TaxLineBreakdown
+		if config.Mode == TaxInclusive {
+			breakdown = splitInclusive(line.Amount, config.ServiceChargeRate, taxRate, config.ServiceBeforeTax)
+		} else {
+			breakdown = addExclusive(line.Amount, config.ServiceChargeRate, taxRate, config.ServiceBeforeTax)
+		}
+
+		breakdown.Name = line.Name
+		breakdown.Category = line.Category
+		breakdown.Exempt = exempt[line.Category]
+
+		result.Lines = append(result.Lines, breakdown)
+		result.Subtotal += breakdown.Net
+		result.ServiceCharge += breakdown.ServiceCharge
+		result.Tax += breakdown.Tax
+	}
+
+	total := result.Subtotal + result.ServiceCharge + result.Tax
+	result.Total, _ = RoundAmount(total, config.Rounding, config.RoundingUnit)
+	result.Rounding = result.Total - total
+
+	result.FormattedSubtotal = FormatRupiah(result.Subtotal)
+	result.FormattedServiceCharge = FormatRupiah(result.ServiceCharge)
+	result.FormattedTax = FormatRupiah(result.Tax)
+	result.FormattedRounding = FormatRupiah(result.Rounding)
+	result.FormattedTotal = FormatRupiah(result.Total)
+
+	return result, nil
+}
+
+// RoundAmount rounds to a multiple of unit. Halves round up, toward positive
+// infinity, so -150 becomes -100 with RoundNearest. A unit of 1 or less
+// leaves the amount as it is.
+func RoundAmount(amount int64, rule RoundingRule, unit int64) (int64, error) {
+	if !isRoundingRule(rule) {
+		return amount, errors.New("invalid_rounding_rule")
+	}
+
+	if unit <= 1 || rule == "" || rule == RoundNone {
+		return amount, nil
+	}
+
+	remainder := amount % unit
+	if remainder == 0 {
+		return amount, nil
+	}
+
+	if remainder < 0 {
+		remainder += unit
+	}
+	floor := amount - remainder
+
+	switch rule {
+	case RoundUp:
+		return floor + unit, nil
+	case RoundDown:
+		return floor, nil
+	default:
+		if remainder*2 >= unit {
+			return floor + unit, nil
+		}
+		return floor, nil
+	}
+}
+
+func isRoundingRule(rule RoundingRule) bool {
+	switch rule {
+	case "", RoundNone, RoundNearest, RoundUp, RoundDown:
+		return true
+	default:
+		return false
+	}
+}
+
+func addExclusive(amount int64, serviceRate, taxRate int, serviceBeforeTax bool) TaxLineBreakdown {
+	service := percentOf(amount, serviceRate)
+
+	base := amount
+	if serviceBeforeTax {
+		base += service
+	}
+	tax := percentOf(base, taxRate)
+
+	return TaxLineBreakdown{
+		Net:           amount,
+		ServiceCharge: service,
+		Tax:           tax,
+		Total:         amount + service + tax,
+	}
+}
+
+func splitInclusive(amount int64, serviceRate, taxRate int, serviceBeforeTax bool) TaxLineBreakdown {
+	// The gross amount is net * multiplier, where the multiplier is expressed in
+	// ten-thousandths so both compounded and flat rates stay in integer math.
+	multiplier := int64(10000 + (serviceRate+taxRate)*100)
+	if serviceBeforeTax {
+		multiplier = int64((100 + serviceRate) * (100 + taxRate))
+	}
+
+	net := divRound(amount*10000, multiplier)
+	service := percentOf(net, serviceRate)
+
+	// Whatever is left after rounding the net and service charge belongs to tax,
+	// so the line always adds back up to the price printed on the menu.
+	tax := amount - net - service
+	if taxRate == 0 {
+		service = amount - net
+		tax = 0
+	}
+
+	return TaxLineBreakdown{
+		Net:           net,
+		ServiceCharge: service,
+		Tax:           tax,
+		Total:         amount,
+	}
+}
+
+func percentOf(amount int64, rate int) int64 {
+	return divRound(amount*int64(rate), 100)
+}
+
+func divRound(numerator, denominator int64) int64 {
+	if numerator < 0 {
+		return -divRound(-numerator, denominator)
+	}
+	return (numerator + denominator/2) / denominator
+}
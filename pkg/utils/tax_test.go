@@ -0,0 +1,203 @@
+package utils
+
+import "testing"
+
+func TestRoundAmount(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount int64
+		rule   RoundingRule
+		unit   int64
+		want   int64
+	}{
+		{"none keeps amount", 12345, RoundNone, 100, 12345},
+		{"empty rule keeps amount", 12345, "", 100, 12345},
+		{"nearest below half", 12349, RoundNearest, 100, 12300},
+		{"nearest exactly half", 12350, RoundNearest, 100, 12400},
+		{"nearest above half", 12351, RoundNearest, 100, 12400},
+		{"nearest already a multiple", 12300, RoundNearest, 100, 12300},
+		{"up", 12301, RoundUp, 100, 12400},
+		{"up already a multiple", 12300, RoundUp, 100, 12300},
+		{"down", 12399, RoundDown, 100, 12300},
+		{"nearest to 500", 12250, RoundNearest, 500, 12500},
+		{"unit of one", 12345, RoundNearest, 1, 12345},
+		{"unit of zero", 12345, RoundUp, 0, 12345},
+		{"negative unit", 12345, RoundDown, -100, 12345},
+		{"negative nearest half", -150, RoundNearest, 100, -100},
+		{"negative nearest below half", -151, RoundNearest, 100, -200},
+		{"negative up", -150, RoundUp, 100, -100},
+		{"negative down", -150, RoundDown, 100, -200},
+		{"zero", 0, RoundUp, 100, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RoundAmount(tt.amount, tt.rule, tt.unit)
+			if err != nil {
+				t.Fatalf("RoundAmount(%d, %q, %d) returned error: %v", tt.amount, tt.rule, tt.unit, err)
+			}
+			if got != tt.want {
+				t.Errorf("RoundAmount(%d, %q, %d) = %d, want %d", tt.amount, tt.rule, tt.unit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundAmountRejectsUnknownRule(t *testing.T) {
+	if _, err := RoundAmount(12345, "bankers", 100); err == nil {
+		t.Fatal("expected an error for an unknown rounding rule")
+	}
+}
+
+func TestCalculateTaxLines(t *testing.T) {
+	tests := []struct {
+		name   string
+		config TaxConfig
+		line   TaxLine
+		want   TaxLineBreakdown
+	}{
+		{
+			name:   "exclusive flat",
+			config: TaxConfig{TaxRate: 10, ServiceChargeRate: 5, Mode: TaxExclusive},
+			line:   TaxLine{Amount: 10000},
+			want:   TaxLineBreakdown{Net: 10000, ServiceCharge: 500, Tax: 1000, Total: 11500},
+		},
+		{
+			name:   "exclusive compounded",
+			config: TaxConfig{TaxRate: 10, ServiceChargeRate: 5, Mode: TaxExclusive, ServiceBeforeTax: true},
+			line:   TaxLine{Amount: 10000},
+			want:   TaxLineBreakdown{Net: 10000, ServiceCharge: 500, Tax: 1050, Total: 11550},
+		},
+		{
+			name:   "exclusive rounds half up per line",
+			config: TaxConfig{TaxRate: 10, ServiceChargeRate: 5, Mode: TaxExclusive, ServiceBeforeTax: true},
+			line:   TaxLine{Amount: 12345},
+			want:   TaxLineBreakdown{Net: 12345, ServiceCharge: 617, Tax: 1296, Total: 14258},
+		},
+		{
+			name:   "inclusive flat",
+			config: TaxConfig{TaxRate: 10, ServiceChargeRate: 5, Mode: TaxInclusive},
+			line:   TaxLine{Amount: 11500},
+			want:   TaxLineBreakdown{Net: 10000, ServiceCharge: 500, Tax: 1000, Total: 11500},
+		},
+		{
+			name:   "inclusive compounded",
+			config: TaxConfig{TaxRate: 10, ServiceChargeRate: 5, Mode: TaxInclusive, ServiceBeforeTax: true},
+			line:   TaxLine{Amount: 11550},
+			want:   TaxLineBreakdown{Net: 10000, ServiceCharge: 500, Tax: 1050, Total: 11550},
+		},
+		{
+			name:   "inclusive PPN only",
+			config: TaxConfig{TaxRate: 11, Mode: TaxInclusive},
+			line:   TaxLine{Amount: 11100},
+			want:   TaxLineBreakdown{Net: 10000, ServiceCharge: 0, Tax: 1100, Total: 11100},
+		},
+		{
+			name:   "inclusive remainder goes to tax",
+			config: TaxConfig{TaxRate: 10, ServiceChargeRate: 5, Mode: TaxInclusive, ServiceBeforeTax: true},
+			line:   TaxLine{Amount: 12345},
+			want:   TaxLineBreakdown{Net: 10688, ServiceCharge: 534, Tax: 1123, Total: 12345},
+		},
+		{
+			name:   "exclusive exempt line",
+			config: TaxConfig{TaxRate: 10, ServiceChargeRate: 5, Mode: TaxExclusive, ExemptCategories: []string{"retail"}},
+			line:   TaxLine{Category: "retail", Amount: 10000},
+			want:   TaxLineBreakdown{Category: "retail", Net: 10000, ServiceCharge: 500, Tax: 0, Total: 10500, Exempt: true},
+		},
+		{
+			name:   "inclusive exempt line",
+			config: TaxConfig{TaxRate: 10, ServiceChargeRate: 5, Mode: TaxInclusive, ServiceBeforeTax: true, ExemptCategories: []string{"retail"}},
+			line:   TaxLine{Category: "retail", Amount: 10500},
+			want:   TaxLineBreakdown{Category: "retail", Net: 10000, ServiceCharge: 500, Tax: 0, Total: 10500, Exempt: true},
+		},
+		{
+			name:   "exclusive negative amount",
+			config: TaxConfig{TaxRate: 10, ServiceChargeRate: 5, Mode: TaxExclusive, ServiceBeforeTax: true},
+			line:   TaxLine{Amount: -10000},
+			want:   TaxLineBreakdown{Net: -10000, ServiceCharge: -500, Tax: -1050, Total: -11550},
+		},
+		{
+			name:   "inclusive negative amount",
+			config: TaxConfig{TaxRate: 10, ServiceChargeRate: 5, Mode: TaxInclusive, ServiceBeforeTax: true},
+			line:   TaxLine{Amount: -11550},
+			want:   TaxLineBreakdown{Net: -10000, ServiceCharge: -500, Tax: -1050, Total: -11550},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CalculateTax(tt.config, []TaxLine{tt.line})
+			if err != nil {
+				t.Fatalf("CalculateTax returned error: %v", err)
+			}
+			if len(got.Lines) != 1 {
+				t.Fatalf("got %d lines, want 1", len(got.Lines))
+			}
+			if got.Lines[0] != tt.want {
+				t.Errorf("line = %+v, want %+v", got.Lines[0], tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateTaxTotals(t *testing.T) {
+	config := TaxConfig{
+		TaxName:           "PB1",
+		TaxRate:           10,
+		ServiceChargeRate: 5,
+		Mode:              TaxExclusive,
+		ServiceBeforeTax:  true,
+		Rounding:          RoundNearest,
+		RoundingUnit:      100,
+	}
+
+	got, err := CalculateTax(config, []TaxLine{
+		{Name: "Nasi Goreng", Amount: 10000},
+		{Name: "Es Teh", Amount: 12345},
+	})
+	if err != nil {
+		t.Fatalf("CalculateTax returned error: %v", err)
+	}
+
+	checks := []struct {
+		field string
+		got   any
+		want  any
+	}{
+		{"TaxRate", got.TaxRate, "10%"},
+		{"ServiceChargeRate", got.ServiceChargeRate, "5%"},
+		{"Subtotal", got.Subtotal, int64(22345)},
+		{"ServiceCharge", got.ServiceCharge, int64(1117)},
+		{"Tax", got.Tax, int64(2346)},
+		{"Rounding", got.Rounding, int64(-8)},
+		{"Total", got.Total, int64(25800)},
+		{"FormattedRounding", got.FormattedRounding, "-Rp8"},
+		{"FormattedTotal", got.FormattedTotal, "Rp25.800"},
+	}
+
+	for _, check := range checks {
+		if check.got != check.want {
+			t.Errorf("%s = %v, want %v", check.field, check.got, check.want)
+		}
+	}
+}
+
+func TestCalculateTaxRejectsInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config TaxConfig
+	}{
+		{"unknown rounding rule", TaxConfig{TaxRate: 10, Rounding: "bankers", RoundingUnit: 100}},
+		{"unknown mode", TaxConfig{TaxRate: 10, Mode: "gross"}},
+		{"negative tax rate", TaxConfig{TaxRate: -100, Mode: TaxInclusive, ServiceBeforeTax: true}},
+		{"negative service charge rate", TaxConfig{TaxRate: 10, ServiceChargeRate: -110, Mode: TaxInclusive}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CalculateTax(tt.config, []TaxLine{{Amount: 10000}}); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
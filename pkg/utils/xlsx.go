@@ -0,0 +1,242 @@
+package utils
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	xlsxStyleDefault = iota
+	xlsxStyleHeader
+	xlsxStyleCurrency
+	xlsxStyleDate
+)
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/></Types>`
+
+const xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+
+const xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`
+
+const xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><numFmts count="2"><numFmt numFmtId="164" formatCode="&quot;Rp&quot;#,##0"/><numFmt numFmtId="165" formatCode="dd/mm/yyyy hh:mm"/></numFmts><fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts><fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills><borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders><cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs><cellXfs count="4"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/><xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/><xf numFmtId="165" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs></styleSheet>`
+
+// The header row is frozen so it stays visible while scrolling.
+const xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews><cols><col min="1" max="%d" width="22" customWidth="1"/></cols><sheetData>`
+
+const xlsxSheetFooter = `</sheetData></worksheet>`
+
+var xlsxEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+type xlsxExportWriter struct {
+	zip     *zip.Writer
+	sheet   *bufio.Writer
+	columns []ExportColumn
+	row     int
+}
+
+func newXLSXWriter(w io.Writer, sheetName string, columns []ExportColumn) (*xlsxExportWriter, error) {
+	archive := zip.NewWriter(w)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, xlsxEscape(xlsxSheetName(sheetName)))},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+	}
+
+	for _, part := range parts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	writer := &xlsxExportWriter{
+		zip:     archive,
+		sheet:   bufio.NewWriter(sheet),
+		columns: columns,
+	}
+
+	width := len(columns)
+	if width == 0 {
+		width = 1
+	}
+	fmt.Fprintf(writer.sheet, xlsxSheetHeader, width)
+
+	headers := make([]any, len(columns))
+	for i, column := range columns {
+		headers[i] = column.Header
+	}
+
+	if err := writer.writeRow(headers, true); err != nil {
+		return nil, err
+	}
+
+	return writer, nil
+}
+
+func (w *xlsxExportWriter) WriteRow(values ...any) error {
+	return w.writeRow(values, false)
+}
+
+func (w *xlsxExportWriter) Close() error {
+	if _, err := w.sheet.WriteString(xlsxSheetFooter); err != nil {
+		return err
+	}
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+	return w.zip.Close()
+}
+
+func (w *xlsxExportWriter) writeRow(values []any, header bool) error {
+	w.row++
+	fmt.Fprintf(w.sheet, `<row r="%d">`, w.row)
+
+	for i, value := range values {
+		ref := xlsxColumnName(i) + strconv.Itoa(w.row)
+
+		cellType := ExportText
+		if !header && i < len(w.columns) {
+			cellType = w.columns[i].Type
+		}
+
+		style := xlsxStyleDefault
+		if header {
+			style = xlsxStyleHeader
+		}
+
+		w.writeCell(ref, value, cellType, style)
+	}
+
+	if _, err := w.sheet.WriteString(`</row>`); err != nil {
+		return err
+	}
+
+	// Flush each row so the archive keeps streaming to the client.
+	return w.sheet.Flush()
+}
+
+func (w *xlsxExportWriter) writeCell(ref string, value any, cellType ExportCellType, style int) {
+	switch cellType {
+	case ExportNumber, ExportCurrency:
+		number, ok := xlsxNumber(value)
+		if !ok {
+			break
+		}
+		if cellType == ExportCurrency {
+			style = xlsxStyleCurrency
+		}
+		fmt.Fprintf(w.sheet, `<c r="%s" s="%d"><v>%s</v></c>`, ref, style, number)
+		return
+	case ExportDate:
+		serial, ok := xlsxDate(value)
+		if !ok {
+			break
+		}
+		fmt.Fprintf(w.sheet, `<c r="%s" s="%d"><v>%s</v></c>`, ref, xlsxStyleDate, serial)
+		return
+	}
+
+	text := exportString(value)
+	if text == "" {
+		return
+	}
+
+	fmt.Fprintf(w.sheet, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, style, xlsxEscape(text))
+}
+
+func xlsxNumber(value any) (string, bool) {
+	switch v := value.(type) {
+	case int, int64, uint, float64:
+		return exportString(v), true
+	default:
+		return "", false
+	}
+}
+
+func xlsxDate(value any) (string, bool) {
+	var t time.Time
+
+	switch v := value.(type) {
+	case time.Time:
+		t = v
+	case *time.Time:
+		if v == nil {
+			return "", false
+		}
+		t = *v
+	default:
+		return "", false
+	}
+
+	if t.IsZero() {
+		return "", false
+	}
+
+	// Excel stores dates as days since its epoch in local wall-clock time.
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	days := wall.Sub(xlsxEpoch).Hours() / 24
+
+	return strconv.FormatFloat(days, 'f', 6, 64), true
+}
+
+func xlsxColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+func xlsxSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+
+	if name == "" {
+		name = "Sheet1"
+	}
+
+	if len([]rune(name)) > 31 {
+		name = string([]rune(name)[:31])
+	}
+
+	return name
+}
+
+func xlsxEscape(text string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(text))
+	return b.String()
+}
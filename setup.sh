@@ -17,6 +17,9 @@ sudo find . \( -path ./node_modules -o -path ./vendor \) -prune -o -type f -exec
 echo "--> Setting writable permissions..."
 sudo chmod 755 runner-app
 
+echo "--> Running database migrations..."
+sudo ./runner-app -migrate || exit 1
+
 echo "--> Supervisor setup..."
 sudo cp ./deploy/supervisor.conf /etc/supervisor/conf.d/simple_pos_api_novaardiansyah_id.conf
 